@@ -65,3 +65,16 @@ func badType(t string) trim.Response {
 		trim.CodeBadRequest,
 	)
 }
+
+// badValue returns an error trim.Response when a value of a type passed in a
+// query string is invalid for the reason in the error.
+func badValue(t string, err error) trim.Response {
+	return response.NewJSON(
+		map[string]string{
+			"message": fmt.Sprintf(
+				"must pass a valid %s: %v", t, err,
+			),
+		},
+		trim.CodeBadRequest,
+	)
+}
@@ -23,7 +23,8 @@ func (c movesController) Description() *application.ControllerDescription {
 	return &application.ControllerDescription{
 		Get: &application.MethodDescription{
 			FormArguments: map[string]string{
-				movesStateKey: "State to find moves for",
+				movesStateKey: "State to find moves for as JSON " +
+					"or a position",
 			},
 			Response:       "Moves for each Piece",
 			Authentication: "must provide Token",
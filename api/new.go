@@ -11,9 +11,14 @@ import (
 	"github.com/jwowillo/trim/response"
 )
 
-// errBadPlayer is an error trim.Response returned when a bad value is provided
-// for the game.Player type.
-var errBadPlayer = badType("game.Player")
+var (
+	// errBadPlayer is an error trim.Response returned when a bad value is
+	// provided for the game.Player type.
+	errBadPlayer = badType("game.Player")
+	// errBadPlayMode is an error trim.Response returned when a bad value is
+	// provided for the game.PlayMode type.
+	errBadPlayMode = badType("game.PlayMode")
+)
 
 const (
 	// newPath is the newController's path.
@@ -26,6 +31,9 @@ const (
 	newPlayer2Key     = "player2"
 	newJSONPlayer1Key = "json-player1"
 	newJSONPlayer2Key = "json-player2"
+	// newModeKey is the key for the game.PlayMode passed in the
+	// trim.Context.
+	newModeKey = "mode"
 )
 
 // newController is a trim.Controller used to create new game.States to play
@@ -44,6 +52,8 @@ func (c newController) Description() *application.ControllerDescription {
 			FormArguments: map[string]string{
 				newPlayer1Key: "Player for player 1",
 				newPlayer2Key: "Player for player 2",
+				"?" + newModeKey: "optional alternating or " +
+					"simultaneous mode for Plays",
 			},
 			Response:       "initial State",
 			Authentication: "must provide Token",
@@ -68,7 +78,11 @@ func (c newController) Handle(r trim.Request) trim.Response {
 	p2 := r.Context()[newPlayer2Key].(game.DescribedPlayer)
 	jp1 := r.Context()[newJSONPlayer1Key].(convert.JSONPlayer)
 	jp2 := r.Context()[newJSONPlayer2Key].(convert.JSONPlayer)
-	s := game.NewState(game.StandardRules, p1, p2)
+	rules := game.StandardRules
+	if mode, ok := r.Context()[newModeKey]; ok {
+		rules = rules.WithPlayMode(mode.(game.PlayMode))
+	}
+	s := game.NewState(rules, p1, p2)
 	js := convert.StateToJSONState(s)
 	js.Player1 = jp1
 	js.Player2 = jp2
@@ -118,5 +132,18 @@ func (v validateNew) Handle(r trim.Request) trim.Response {
 	}
 	r.SetContext(newPlayer1Key, p1)
 	r.SetContext(newPlayer2Key, p2)
+	if modeArgs, ok := r.FormArgs()[newModeKey]; ok {
+		if len(modeArgs) != 1 {
+			return errBadPlayMode
+		}
+		switch modeArgs[0] {
+		case game.AlternatingPlays.String():
+			r.SetContext(newModeKey, game.AlternatingPlays)
+		case game.SimultaneousPlays.String():
+			r.SetContext(newModeKey, game.SimultaneousPlays)
+		default:
+			return errBadPlayMode
+		}
+	}
 	return v.handler.Handle(r)
 }
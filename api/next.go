@@ -5,6 +5,7 @@ import (
 
 	"github.com/jwowillo/landgrab/convert"
 	"github.com/jwowillo/landgrab/game"
+	"github.com/jwowillo/landgrab/notation"
 	"github.com/jwowillo/landgrab/player"
 	"github.com/jwowillo/trim"
 	"github.com/jwowillo/trim/application"
@@ -26,6 +27,9 @@ const (
 	nextStateKey     = "state"
 	nextJSONStateKey = "json-state"
 	nextPlayKey      = "play"
+	// nextPlaysKey is the key for the game.Plays of every game.Player in
+	// games with simultaneous game.Plays.
+	nextPlaysKey = "plays"
 )
 
 // nextController is a trim.Controller used to get the next game.State from a
@@ -42,10 +46,13 @@ func (c nextController) Description() *application.ControllerDescription {
 	return &application.ControllerDescription{
 		Get: &application.MethodDescription{
 			FormArguments: map[string]string{
-				nextStateKey:      "State to find the next State of",
+				nextStateKey: "State to find the next State " +
+					"of as JSON or a position",
 				"?" + nextPlayKey: "optional Play to use for the next State",
+				"?" + nextPlaysKey: "optional Plays for each " +
+					"player in simultaneous games",
 			},
-			Response:       "next State",
+			Response:       "next State with the events of the Play",
 			Authentication: "must provide Token",
 			Limiting:       "limit of the Token",
 		},
@@ -67,14 +74,27 @@ func (c nextController) Handle(r trim.Request) trim.Response {
 	s := r.Context()[nextStateKey].(*game.State)
 	ojs := r.Context()[nextJSONStateKey].(convert.JSONState)
 	p, ok := r.Context()[nextPlayKey]
-	if ok {
-		s = game.NextStateWithPlay(s, p.(game.Play))
+	ps, psOk := r.Context()[nextPlaysKey]
+	if psOk {
+		n, err := game.NextStateWithCheckedPlays(
+			s,
+			ps.(map[game.PlayerID]game.Play),
+		)
+		if err != nil {
+			return badValue("game.Play", err)
+		}
+		s = n
+	} else if ok {
+		n, err := game.NextStateWithCheckedPlay(s, p.(game.Play))
+		if err != nil {
+			return badValue("game.Play", err)
+		}
+		s = n
 	} else {
 		s = game.NextState(s)
 	}
 	js := convert.StateToJSONState(s)
-	js.Player1 = ojs.Player1
-	js.Player2 = ojs.Player2
+	js.SetPlayers(ojs.Players()[:s.Rules().PlayerCount()])
 	return response.NewJSON(js, trim.CodeOK)
 }
 
@@ -112,9 +132,29 @@ func (v validateNext) Handle(r trim.Request) trim.Response {
 		}
 		r.SetContext(nextPlayKey, p)
 	}
+	psArgs, ok := r.FormArgs()[nextPlaysKey]
+	if ok {
+		if len(psArgs) != 1 {
+			return errBadPlay
+		}
+		unquoted, err := url.QueryUnescape(psArgs[0])
+		if err != nil {
+			return errBadPlay
+		}
+		ps, err := convert.JSONToPlays([]byte(unquoted))
+		if err != nil {
+			return errBadPlay
+		}
+		r.SetContext(nextPlaysKey, ps)
+	}
 	return v.handler.Handle(r)
 }
 
+// parseState from the trim.Request's form argument at the key into the
+// trim.Context at the key along with its convert.JSONState at the JSON key.
+//
+// The game.State can be given as JSON or as a position in the form
+// notation.FormatPosition returns.
 func parseState(r trim.Request, skey, jskey string) trim.Response {
 	sArgs := r.FormArgs()[skey]
 	if len(sArgs) != 1 {
@@ -124,12 +164,24 @@ func parseState(r trim.Request, skey, jskey string) trim.Response {
 	if err != nil {
 		return errBadState
 	}
+	// notation.ParsePosition and convert.JSONStateToState check the
+	// game.Rules before the game.State is created so clients can't make
+	// the server allocate huge boards.
+	var s *game.State
 	js, err := convert.JSONToJSONState([]byte(unquoted))
+	if err == nil {
+		s, err = convert.JSONStateToState(js, player.Factory)
+	} else {
+		s, err = notation.ParsePosition(unquoted)
+		if err == nil {
+			js = convert.StateToJSONState(s)
+		}
+	}
 	if err != nil {
-		return errBadState
+		return badValue("game.State", err)
 	}
-	s := convert.JSONStateToState(js, player.Factory)
-	if s.Rules() != game.StandardRules {
+	simultaneous := game.StandardRules.WithPlayMode(game.SimultaneousPlays)
+	if s.Rules() != game.StandardRules && s.Rules() != simultaneous {
 		return errBadState
 	}
 	r.SetContext(jskey, js)
@@ -1,11 +1,20 @@
 package arena
 
-import "github.com/jwowillo/landgrab/game"
+import (
+	"context"
+
+	"github.com/jwowillo/landgrab/game"
+)
 
 // CumulativeResult ...
+//
+// Players has a PlayerSummary for every game.Player in order.
 type CumulativeResult struct {
 	Player1Wins          int
 	Player2Wins          int
+	Draws                int
+	Player1Timeouts      int
+	Player2Timeouts      int
 	Player1AveragePieces float64
 	Player1AverageLife   float64
 	Player1AverageDamage float64
@@ -13,82 +22,213 @@ type CumulativeResult struct {
 	Player2AverageLife   float64
 	Player2AverageDamage float64
 	AverageTurns         float64
+	Players              []PlayerSummary
+}
+
+// PlayerSummary of how a game.Player did over many games.
+//
+// A game.Player's placing is 1 if they won or survived a draw and otherwise
+// counts down from the number of game.Players in the order they were
+// eliminated.
+type PlayerSummary struct {
+	Wins           int
+	Timeouts       int
+	AveragePieces  float64
+	AverageLife    float64
+	AverageDamage  float64
+	AveragePlacing float64
 }
 
 // Result ....
+//
+// Players has a PlayerResult for every game.Player in order.
 type Result struct {
-	Winner        game.PlayerID
-	Player1Pieces float64
-	Player1Life   float64
-	Player1Damage float64
-	Player2Pieces float64
-	Player2Life   float64
-	Player2Damage float64
-	Turns         int
+	Winner          game.PlayerID
+	Draw            bool
+	Player1Timeouts int
+	Player2Timeouts int
+	Player1Pieces   float64
+	Player1Life     float64
+	Player1Damage   float64
+	Player2Pieces   float64
+	Player2Life     float64
+	Player2Damage   float64
+	Turns           int
+	Eliminated      []game.PlayerID
+	Players         []PlayerResult
+}
+
+// PlayerResult of how a game.Player did in a single game.
+//
+// Placing is the same as in PlayerSummary.
+type PlayerResult struct {
+	Timeouts int
+	Pieces   float64
+	Life     float64
+	Damage   float64
+	Placing  int
 }
 
 // Run ...
 func Run(rules game.Rules, p1, p2 game.Player, n int) CumulativeResult {
-	results := make(chan Result)
+	result, _ := RunContext(context.Background(), rules, p1, p2, n)
+	return result
+}
+
+// RunContext ...
+//
+// Games still being played when the context.Context is done are aborted and
+// left out of the CumulativeResult, which is returned along with the
+// context.Context's error.
+func RunContext(
+	ctx context.Context,
+	rules game.Rules,
+	p1, p2 game.Player,
+	n int,
+) (CumulativeResult, error) {
+	return RunPlayersContext(ctx, rules, []game.Player{p1, p2}, n)
+}
+
+// RunPlayersContext is RunContext for games between any number of game.Players
+// which play in order.
+//
+// There should be a game.Player for each of the game.Rules' PlayerIDs.
+func RunPlayersContext(
+	ctx context.Context,
+	rules game.Rules,
+	ps []game.Player,
+	n int,
+) (CumulativeResult, error) {
+	type single struct {
+		result Result
+		err    error
+	}
+	results := make(chan single)
 	for i := 0; i < n; i++ {
-		go func(results chan Result) {
-			results <- RunSingle(rules, p1, p2)
+		go func(results chan single) {
+			r, err := RunSinglePlayersContext(ctx, rules, ps)
+			results <- single{result: r, err: err}
 		}(results)
 	}
-	result := CumulativeResult{}
+	result := CumulativeResult{
+		Players: make([]PlayerSummary, rules.PlayerCount()),
+	}
+	played := 0
 	for i := 0; i < n; i++ {
-		r := <-results
-		if r.Winner == game.Player1 {
-			result.Player1Wins++
+		x := <-results
+		if x.err != nil {
+			continue
 		}
-		if r.Winner == game.Player2 {
-			result.Player2Wins++
+		played++
+		r := x.result
+		if r.Draw {
+			result.Draws++
+		}
+		for j, pr := range r.Players {
+			summary := &result.Players[j]
+			if r.Winner == game.PlayerID(j+1) {
+				summary.Wins++
+			}
+			summary.Timeouts += pr.Timeouts
+			summary.AveragePieces += pr.Pieces
+			summary.AverageLife += pr.Life
+			summary.AverageDamage += pr.Damage
+			summary.AveragePlacing += float64(pr.Placing)
 		}
-		result.Player1AveragePieces += r.Player1Pieces
-		result.Player1AverageLife += r.Player1Life
-		result.Player1AverageDamage += r.Player1Damage
-		result.Player2AveragePieces += r.Player2Pieces
-		result.Player2AverageLife += r.Player2Life
-		result.Player2AverageDamage += r.Player2Damage
 		result.AverageTurns += float64(r.Turns)
 	}
-	result.Player1AveragePieces /= float64(n)
-	result.Player1AverageLife /= float64(n)
-	result.Player1AverageDamage /= float64(n)
-	result.Player2AveragePieces /= float64(n)
-	result.Player2AverageLife /= float64(n)
-	result.Player2AverageDamage /= float64(n)
-	result.AverageTurns /= float64(n)
-	return result
+	if played != 0 {
+		for j := range result.Players {
+			summary := &result.Players[j]
+			summary.AveragePieces /= float64(played)
+			summary.AverageLife /= float64(played)
+			summary.AverageDamage /= float64(played)
+			summary.AveragePlacing /= float64(played)
+		}
+		result.AverageTurns /= float64(played)
+	}
+	p1, p2 := result.Players[0], result.Players[1]
+	result.Player1Wins = p1.Wins
+	result.Player1Timeouts = p1.Timeouts
+	result.Player1AveragePieces = p1.AveragePieces
+	result.Player1AverageLife = p1.AverageLife
+	result.Player1AverageDamage = p1.AverageDamage
+	result.Player2Wins = p2.Wins
+	result.Player2Timeouts = p2.Timeouts
+	result.Player2AveragePieces = p2.AveragePieces
+	result.Player2AverageLife = p2.AverageLife
+	result.Player2AverageDamage = p2.AverageDamage
+	return result, ctx.Err()
 }
 
 // RunSingle ...
 func RunSingle(rules game.Rules, p1, p2 game.Player) Result {
-	r := Result{}
-	s := game.NewState(rules, p1, p2)
-	for s.Winner() == game.NoPlayer {
-		s = game.NextState(s)
+	r, _ := RunSingleContext(context.Background(), rules, p1, p2)
+	return r
+}
+
+// RunSingleContext ...
+//
+// The game is aborted once the context.Context is done and the
+// context.Context's error is returned.
+func RunSingleContext(
+	ctx context.Context,
+	rules game.Rules,
+	p1, p2 game.Player,
+) (Result, error) {
+	return RunSinglePlayersContext(ctx, rules, []game.Player{p1, p2})
+}
+
+// RunSinglePlayersContext is RunSingleContext for a game between any number of
+// game.Players which play in order.
+//
+// There should be a game.Player for each of the game.Rules' PlayerIDs.
+func RunSinglePlayersContext(
+	ctx context.Context,
+	rules game.Rules,
+	ps []game.Player,
+) (Result, error) {
+	r := Result{Players: make([]PlayerResult, rules.PlayerCount())}
+	s := game.NewStateWithPlayers(rules, ps)
+	for !s.IsOver() {
+		var err error
+		s, err = game.NextStateContext(ctx, s)
+		if err != nil {
+			return r, err
+		}
 		r.Turns++
+		for _, id := range s.TimedOutPlayers() {
+			r.Players[id-1].Timeouts++
+		}
 	}
 	r.Winner = s.Winner()
-	for _, p := range s.Player1Pieces() {
-		r.Player1Pieces++
-		r.Player1Life += float64(p.Life())
-		r.Player1Damage += float64(p.Damage())
-	}
-	for _, p := range s.Player2Pieces() {
-		r.Player2Pieces++
-		r.Player2Life += float64(p.Life())
-		r.Player2Damage += float64(p.Damage())
-	}
-	if len(s.Player1Pieces()) != 0 {
-		r.Player1Life /= float64(len(s.Player1Pieces()))
-		r.Player1Damage /= float64(len(s.Player1Pieces()))
+	r.Draw = s.IsDraw()
+	r.Eliminated = s.Eliminated()
+	for i := range r.Players {
+		pr := &r.Players[i]
+		pr.Placing = 1
+		pieces := s.PlayerPieces(game.PlayerID(i + 1))
+		for _, p := range pieces {
+			pr.Pieces++
+			pr.Life += float64(p.Life())
+			pr.Damage += float64(p.Damage())
+		}
+		if len(pieces) != 0 {
+			pr.Life /= float64(len(pieces))
+			pr.Damage /= float64(len(pieces))
+		}
 	}
-	if len(s.Player2Pieces()) != 0 {
-		r.Player2Life /= float64(len(s.Player2Pieces()))
-		r.Player2Damage /= float64(len(s.Player2Pieces()))
+	for i, id := range r.Eliminated {
+		r.Players[id-1].Placing = len(r.Players) - i
 	}
-	return r
-
+	p1, p2 := r.Players[0], r.Players[1]
+	r.Player1Timeouts = p1.Timeouts
+	r.Player1Pieces = p1.Pieces
+	r.Player1Life = p1.Life
+	r.Player1Damage = p1.Damage
+	r.Player2Timeouts = p2.Timeouts
+	r.Player2Pieces = p2.Pieces
+	r.Player2Life = p2.Life
+	r.Player2Damage = p2.Damage
+	return r, nil
 }
@@ -9,10 +9,13 @@ import (
 
 	"github.com/jwowillo/landgrab/convert"
 	"github.com/jwowillo/landgrab/game"
+	"github.com/jwowillo/landgrab/notation"
 )
 
 // CLI ...
 type CLI struct {
+	rules      game.Rules
+	position   string
 	rw         io.ReadWriter
 	writeFunc  func()
 	shouldWait bool
@@ -21,6 +24,7 @@ type CLI struct {
 // New ...
 func New(r io.Reader, w io.Writer, wf func(), sw bool) *CLI {
 	return &CLI{
+		rules: game.StandardRules,
 		rw: struct {
 			io.Reader
 			io.Writer
@@ -30,69 +34,191 @@ func New(r io.Reader, w io.Writer, wf func(), sw bool) *CLI {
 	}
 }
 
+// SetRules the games are played with.
+//
+// Games are played with game.StandardRules by default.
+func (cli *CLI) SetRules(r game.Rules) {
+	cli.rules = r
+}
+
+// SetPosition the games start at in the form notation.FormatPosition returns.
+//
+// The games are played with the position's rules. An error is returned if the
+// position is invalid.
+func (cli *CLI) SetPosition(position string) error {
+	s, err := notation.ParsePosition(position)
+	if err != nil {
+		return err
+	}
+	cli.rules = s.Rules()
+	cli.position = position
+	return nil
+}
+
 // Run ...
 //
 // If player 1 or player 2 are nil, ask for them.
 func (cli *CLI) Run(factory *game.PlayerFactory, p1, p2 game.DescribedPlayer) {
+	cli.RunPlayers(factory, []game.DescribedPlayer{p1, p2})
+}
+
+// RunPlayers is Run for games with any number of players.
+//
+// Players which are nil or left out of the rules' player count are asked for.
+func (cli *CLI) RunPlayers(
+	factory *game.PlayerFactory,
+	ps []game.DescribedPlayer,
+) {
 	fmt.Fprintf(cli.rw, clear)
 	fmt.Fprintf(cli.rw, title)
 	fmt.Fprintln(cli.rw)
 	cli.writeFunc()
-	if p1 == nil {
-		p1 = cli.choosePlayer(factory, game.Player1)
+	players := make([]game.Player, cli.rules.PlayerCount())
+	described := make([]game.DescribedPlayer, len(players))
+	for i, id := range cli.rules.PlayerIDs() {
+		if i < len(ps) && ps[i] != nil {
+			described[i] = ps[i]
+		} else {
+			described[i] = cli.choosePlayer(factory, id)
+		}
+		players[i] = described[i]
 	}
-	if p2 == nil {
-		p2 = cli.choosePlayer(factory, game.Player2)
+	s := game.NewStateWithPlayers(cli.rules, players)
+	if cli.position != "" {
+		// The position was already checked when it was set.
+		s, _ = notation.ParsePositionWithPlayers(cli.position, players)
 	}
-	s := game.NewState(game.StandardRules, p1, p2)
-	for s.Winner() == game.NoPlayer {
-		printStateAndPrompt(cli.rw, s)
-		cli.writeFunc()
-		isHuman := false
-		if s.CurrentPlayer() == game.Player1 && p1.Name() == "human" {
-			play := cli.promptPlay(s)
-			p1 = factory.SpecialPlayer("human", play)
-			isHuman = true
+	s = s.WithHistory()
+	for !s.IsOver() {
+		if hasSimultaneousHuman(s, described) {
+			plays, undo := cli.simultaneousPlays(
+				factory, s, described,
+			)
+			if undo {
+				s = undoTurn(s)
+				continue
+			}
+			s = game.NextStateWithPlays(s, plays)
+			continue
 		}
-		if s.CurrentPlayer() == game.Player2 && p2.Name() == "human" {
-			play := cli.promptPlay(s)
-			p2 = factory.SpecialPlayer("human", play)
-			isHuman = true
+		view := shownView(s, described)
+		printStateAndPrompt(cli.rw, view)
+		cli.writeFunc()
+		current := described[s.CurrentPlayer()-1]
+		if current.Name() == "human" {
+			play, undo := cli.promptPlay(view)
+			if undo {
+				s = undoTurn(s)
+				continue
+			}
+			human := factory.SpecialPlayer("human", play)
+			s = game.NextStateWithPlay(s, human.Play(s))
+			continue
 		}
-		s = game.NewStateFromInfo(
-			s.Rules(),
-			s.CurrentPlayer(),
-			p1, p2,
-			pieces(s),
-		)
 		s = game.NextState(s)
-		if cli.shouldWait && !isHuman {
+		if cli.shouldWait {
 			cli.waitForEnter()
 		}
 	}
 	printState(cli.rw, s)
+	fmt.Fprintln(cli.rw)
+	printResult(cli.rw, s)
 	cli.writeFunc()
 }
 
-func pieces(s *game.State) map[game.Cell]game.Piece {
-	ps := make(map[game.Cell]game.Piece)
-	for i := 0; i < s.Rules().BoardSize(); i++ {
-		for j := 0; j < s.Rules().BoardSize(); j++ {
-			c := game.NewCell(i, j)
-			p := s.PieceForCell(c)
-			if p == game.NoPiece {
-				continue
-			}
-			ps[c] = p
+// shownView returns the game.State to show, which is the game.PlayerView of the
+// current game.Player if they're human or of the first human otherwise.
+//
+// The whole game.State is shown if nobody is human.
+func shownView(
+	s *game.State,
+	described []game.DescribedPlayer,
+) *game.State {
+	id := s.CurrentPlayer()
+	if described[id-1].Name() == "human" {
+		return s.PlayerView(id)
+	}
+	for i, p := range described {
+		if p.Name() == "human" {
+			return s.PlayerView(game.PlayerID(i + 1))
 		}
 	}
-	return ps
+	return s
 }
 
-func (cli *CLI) promptPlay(s *game.State) map[string]interface{} {
-	ms := make([][]game.Direction, s.Rules().PieceCount()*2)
+// hasSimultaneousHuman returns true iff the game.State is in a game with
+// game.SimultaneousPlays past the deployment phase and a human game.Player
+// who hasn't been eliminated.
+func hasSimultaneousHuman(
+	s *game.State,
+	described []game.DescribedPlayer,
+) bool {
+	if s.Rules().PlayMode() != game.SimultaneousPlays || s.IsDeploying() {
+		return false
+	}
+	for _, id := range s.Rules().PlayerIDs() {
+		alive := len(s.PlayerPieces(id)) > 0
+		if alive && described[id-1].Name() == "human" {
+			return true
+		}
+	}
+	return false
+}
+
+// simultaneousPlays returns the game.Plays every game.Player who hasn't been
+// eliminated makes from their own view of the game.State.
+//
+// Human game.Players are shown their view and prompted in order. The returned
+// bool is true iff a human game.Player asked to undo their last turn instead.
+func (cli *CLI) simultaneousPlays(
+	factory *game.PlayerFactory,
+	s *game.State,
+	described []game.DescribedPlayer,
+) (map[game.PlayerID]game.Play, bool) {
+	plays := make(map[game.PlayerID]game.Play)
+	for _, id := range s.Rules().PlayerIDs() {
+		if len(s.PlayerPieces(id)) == 0 {
+			continue
+		}
+		view := s.AsPlayer(id).PlayerView(id)
+		if described[id-1].Name() != "human" {
+			plays[id] = s.Player(id).Play(view)
+			continue
+		}
+		printStateAndPrompt(cli.rw, view)
+		cli.writeFunc()
+		play, undo := cli.promptPlay(view)
+		if undo {
+			return nil, true
+		}
+		plays[id] = factory.SpecialPlayer("human", play).Play(view)
+	}
+	return plays, false
+}
+
+// undoTurn returns the game.State at the current game.Player's previous turn or
+// the game.State itself if there is no previous turn.
+func undoTurn(s *game.State) *game.State {
+	id := s.CurrentPlayer()
+	p := game.Undo(s, 1)
+	for p.CurrentPlayer() != id && p.Previous() != nil {
+		p = game.Undo(p, 1)
+	}
+	if p == s || p.CurrentPlayer() != id {
+		return s
+	}
+	return p
+}
+
+// promptPlay prompts for the human game.Player's play in convert.JSONPlay form.
+//
+// The returned bool is true iff the game.Player asked to undo their last turn
+// instead.
+func (cli *CLI) promptPlay(s *game.State) (map[string]interface{}, bool) {
+	pc := s.Rules().PieceCount() * s.Rules().PlayerCount()
+	ms := make([][]string, pc+1)
 	for _, m := range game.LegalMoves(s) {
-		ms[m.Piece().ID()] = append(ms[m.Piece().ID()], m.Direction())
+		ms[m.Piece().ID()] = append(ms[m.Piece().ID()], offsetString(m))
 	}
 	fmt.Fprintf(cli.rw, "\nLegal moves for play:\n")
 	for i, p := range ms {
@@ -102,25 +228,31 @@ func (cli *CLI) promptPlay(s *game.State) map[string]interface{} {
 		fmt.Fprintf(cli.rw, "* Piece %d: %v\n", i, p)
 	}
 	fmt.Fprintf(cli.rw, "\nEnter play as semi-colon separated pairs of piece ID and\n")
-	fmt.Fprintf(cli.rw, "direction [(<id>,<direction>),(<id>,<direction>)...]:\n")
+	fmt.Fprintf(cli.rw, "direction [(<id>,<direction>),(<id>,<direction>)...]\n")
+	fmt.Fprintf(cli.rw, "where directions can also be <rows>:<columns> offsets\n")
+	fmt.Fprintf(cli.rw, "or undo to take back your last turn:\n")
 	cli.writeFunc()
 	playString := ""
 	fmt.Fscanf(cli.rw, "%s", &playString)
+	if playString == "undo" {
+		return nil, true
+	}
 	pairs := strings.Split(playString, ";")
 	play := make([]convert.JSONMove, len(pairs))
+	moves := make(game.Play, len(pairs))
 	for i, pair := range pairs {
 		pair = strings.TrimSpace(pair)
 		if !strings.HasPrefix(pair, "(") || !strings.HasSuffix(pair, ")") {
 			fmt.Fprintf(cli.rw, "\nInvalid play format.\n")
 			cli.waitForEnter()
-			return nil
+			return nil, false
 		}
 		pair = pair[1 : len(pair)-1]
 		move := strings.Split(pair, ",")
 		if len(move) != 2 {
 			fmt.Fprintf(cli.rw, "\nInvalid play format.\n")
 			cli.waitForEnter()
-			return nil
+			return nil, false
 		}
 		move[0] = strings.TrimSpace(move[0])
 		move[1] = strings.TrimSpace(move[1])
@@ -128,30 +260,13 @@ func (cli *CLI) promptPlay(s *game.State) map[string]interface{} {
 		if err != nil {
 			fmt.Fprintf(cli.rw, "\nInvalid play format.\n")
 			cli.waitForEnter()
-			return nil
+			return nil, false
 		}
-		var direction game.Direction
-		switch move[1] {
-		case "north":
-			direction = game.North
-		case "north-east":
-			direction = game.NorthEast
-		case "east":
-			direction = game.East
-		case "south-east":
-			direction = game.SouthEast
-		case "south":
-			direction = game.South
-		case "south-west":
-			direction = game.SouthWest
-		case "west":
-			direction = game.West
-		case "north-west":
-			direction = game.NorthWest
-		default:
+		offset, ok := parseOffset(move[1])
+		if !ok {
 			fmt.Fprintf(cli.rw, "\nInvalid play format.\n")
 			cli.waitForEnter()
-			return nil
+			return nil, false
 		}
 		piece := game.NoPiece
 		for _, p := range s.CurrentPlayerPieces() {
@@ -162,14 +277,51 @@ func (cli *CLI) promptPlay(s *game.State) map[string]interface{} {
 		if piece == game.NoPiece {
 			fmt.Fprintf(cli.rw, "\nInvalid play format.\n")
 			cli.waitForEnter()
-			return nil
+			return nil, false
 		}
-		play[i] = convert.MoveToJSONMove(
-			game.NewMove(piece, direction),
-			s,
-		)
+		moves[i] = game.NewOffsetMove(piece, offset)
+		play[i] = convert.MoveToJSONMove(moves[i], s)
+	}
+	if err := game.CheckPlay(s, moves); err != nil {
+		fmt.Fprintf(cli.rw, "\n%v.\n", err)
+		cli.waitForEnter()
+		return nil, false
+	}
+	return map[string]interface{}{"moves": play}, false
+}
+
+// offsetString is the game.Move's game.Direction or its game.Offset as
+// <rows>:<columns> if it doesn't have one.
+func offsetString(m game.Move) string {
+	if d := m.Direction(); d != game.NoDirection {
+		return d.String()
+	}
+	return fmt.Sprintf("%d:%d", m.Offset().Rows(), m.Offset().Columns())
+}
+
+// parseOffset parses the game.Offset of a game.Direction's name or of
+// <rows>:<columns>.
+//
+// false is returned if the string is neither.
+func parseOffset(x string) (game.Offset, bool) {
+	for _, d := range game.Directions() {
+		if x == d.String() {
+			return d.Offset(), true
+		}
+	}
+	parts := strings.Split(x, ":")
+	if len(parts) != 2 {
+		return game.Offset{}, false
+	}
+	rows, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return game.Offset{}, false
+	}
+	columns, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return game.Offset{}, false
 	}
-	return map[string]interface{}{"moves": play}
+	return game.NewOffset(rows, columns), true
 }
 
 // choosePlayer prompts for a single game.Player for the game.PlayerID and
@@ -227,17 +379,34 @@ const title = `
 const clear = "\033[H\033[2J"
 
 // board string.
+//
+// Cells start with the first letter of the game.Piece's game.Class if any
+// game.Player has a roster. Empty cells owned by a game.Player are their color.
 func board(s *game.State) string {
+	classes := hasRosters(s.Rules())
+	blocked, open, unseen := "██████", "▒▒▒▒▒▒", "░░░░░░"
+	if classes {
+		blocked, open, unseen = blocked+"█", open+"▒", unseen+"░"
+	}
 	out := ""
-	for i := 0; i < s.Rules().BoardSize(); i++ {
-		for j := 0; j < s.Rules().BoardSize(); j++ {
-			p := s.PieceForCell(game.NewCell(i, j))
-			if p == game.NoPiece {
-				out += "▒▒▒▒▒▒"
+	for i := 0; i < s.Rules().BoardHeight(); i++ {
+		for j := 0; j < s.Rules().BoardWidth(); j++ {
+			c := game.NewCell(i, j)
+			p := s.PieceForCell(c)
+			if s.Rules().IsBlocked(c) {
+				out += blocked
+			} else if !s.IsVisible(c) {
+				out += unseen
+			} else if p == game.NoPiece {
+				out += colorForPlayer(s.Owner(c))(open)
 			} else {
+				symbol := ""
+				if classes {
+					symbol = classSymbol(p)
+				}
 				out += colorForPlayer(s.PlayerForPiece(p))(
-					"%2d|%d|%d",
-					p.ID(), p.Life(), p.Damage(),
+					"%s%2d|%d|%d",
+					symbol, p.ID(), p.Life(), p.Damage(),
 				)
 			}
 		}
@@ -246,24 +415,173 @@ func board(s *game.State) string {
 	return strings.TrimSpace(out)
 }
 
+// hasRosters returns true iff any game.Player has a roster in the game.Rules.
+func hasRosters(r game.Rules) bool {
+	for _, id := range r.PlayerIDs() {
+		if len(r.Roster(id)) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// classSymbol is the upper-case first letter of the game.Piece's game.Class
+// or a space for game.StandardClass.
+func classSymbol(p game.Piece) string {
+	if p.Class() == game.StandardClass || p.Class().Name() == "" {
+		return " "
+	}
+	return strings.ToUpper(p.Class().Name()[:1])
+}
+
 // legend string.
-func legend() string {
-	return "cell: PIECE_ID|LIFE|DAMAGE"
+func legend(s *game.State) string {
+	out := "cell: PIECE_ID|LIFE|DAMAGE, blocked cell: ██████"
+	if hasRosters(s.Rules()) {
+		out = "cell: CLASS PIECE_ID|LIFE|DAMAGE, blocked cell: ███████"
+	}
+	if s.Rules().TerritoryControl() > 0 {
+		out += "\n" + territory(s)
+	}
+	if s.Viewer() == game.NoPlayer {
+		return out
+	}
+	unseen := "░░░░░░"
+	if hasRosters(s.Rules()) {
+		unseen += "░"
+	}
+	return fmt.Sprintf(
+		"%s, unseen cell: %s\nviewing as %s",
+		out, unseen, colorForPlayer(s.Viewer())(s.Viewer().String()),
+	)
+}
+
+// territory string listing the cells each game.Player owns out of the cells
+// needed to win.
+func territory(s *game.State) string {
+	r := s.Rules()
+	var scores []string
+	for _, id := range r.PlayerIDs() {
+		scores = append(scores, colorForPlayer(id)(
+			"%s %d", id, s.Territory(id),
+		))
+	}
+	need := (r.TerritoryControl()*r.OpenCells() + 99) / 100
+	return fmt.Sprintf(
+		"Territory: %s (%d to win)",
+		strings.Join(scores, ", "), need,
+	)
+}
+
+// combatLog string describing the game.Events of the last turn in order or ""
+// if nothing happened.
+func combatLog(s *game.State) string {
+	var lines []string
+	for _, e := range s.Events() {
+		piece := fmt.Sprintf("Piece %d", e.Piece)
+		var line string
+		switch e.Kind {
+		case game.MovedEvent:
+			line = fmt.Sprintf(
+				"%s moved from %s to %s",
+				piece, cellString(e.From), cellString(e.To),
+			)
+		case game.BlockedEvent:
+			line = fmt.Sprintf(
+				"%s was blocked moving to %s",
+				piece, cellString(e.To),
+			)
+		case game.AttackedEvent:
+			line = fmt.Sprintf(
+				"%s attacked piece %d for %d damage, "+
+					"leaving it with %d life",
+				piece, e.Target, e.Damage, e.Life,
+			)
+		case game.DestroyedEvent:
+			line = fmt.Sprintf("%s was destroyed", piece)
+		case game.LeveledUpEvent:
+			line = fmt.Sprintf(
+				"%s leveled up to %d|%d",
+				piece, e.Life, e.Damage,
+			)
+		}
+		lines = append(lines, "* "+line)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "Last turn:\n" + strings.Join(lines, "\n")
+}
+
+// cellString formats the game.Cell as (<row>, <column>).
+func cellString(c game.Cell) string {
+	return fmt.Sprintf("(%d, %d)", c.Row(), c.Column())
+}
+
+// eliminated string listing the game.Players in the order they were
+// eliminated.
+func eliminated(s *game.State) string {
+	var ids []string
+	for _, id := range s.Eliminated() {
+		ids = append(ids, colorForPlayer(id)(id.String()))
+	}
+	return "Eliminated: " + strings.Join(ids, ", ")
 }
 
 // prompt string.
 func prompt(s *game.State) string {
-	return fmt.Sprintf("Current player: %s", colorForPlayer(s.CurrentPlayer())(s.CurrentPlayer().String()))
+	out := ""
+	if id := s.TimedOut(); id != game.NoPlayer {
+		out += fmt.Sprintf(
+			"%s ran out of time\n",
+			colorForPlayer(id)(id.String()),
+		)
+	}
+	return out + fmt.Sprintf("Current player: %s", colorForPlayer(s.CurrentPlayer())(s.CurrentPlayer().String()))
+}
+
+// result string.
+//
+// The order Players were eliminated in is included for games with more than 2
+// players.
+func result(s *game.State) string {
+	out := winner(s)
+	if s.Rules().PlayerCount() > 2 && len(s.Eliminated()) != 0 {
+		out += "\n" + eliminated(s)
+	}
+	return out
+}
+
+// winner string.
+func winner(s *game.State) string {
+	if s.IsDraw() {
+		return fmt.Sprintf("Draw after %d turns", s.Turn())
+	}
+	if id := s.Forfeited(); id != game.NoPlayer {
+		return fmt.Sprintf(
+			"Winner: %s (%s ran out of time)",
+			colorForPlayer(s.Winner())(s.Winner().String()),
+			colorForPlayer(id)(id.String()),
+		)
+	}
+	return fmt.Sprintf(
+		"Winner: %s",
+		colorForPlayer(s.Winner())(s.Winner().String()),
+	)
 }
 
 // colorForPlayer returns a formatting function which formats a message and
 // makes its result the appropriate color for the game.PlayerID.
 func colorForPlayer(id game.PlayerID) func(string, ...interface{}) string {
-	if id == game.Player1 {
+	switch id {
+	case game.Player1:
 		return red
-	}
-	if id == game.Player2 {
+	case game.Player2:
 		return blue
+	case game.Player3:
+		return green
+	case game.Player4:
+		return yellow
 	}
 	return fmt.Sprintf
 }
@@ -278,6 +596,16 @@ func blue(s string, args ...interface{}) string {
 	return fmt.Sprintf("\x1b[34;1m%s\x1b[0m", fmt.Sprintf(s, args...))
 }
 
+// green formatting function.
+func green(s string, args ...interface{}) string {
+	return fmt.Sprintf("\x1b[32;1m%s\x1b[0m", fmt.Sprintf(s, args...))
+}
+
+// yellow formatting function.
+func yellow(s string, args ...interface{}) string {
+	return fmt.Sprintf("\x1b[33;1m%s\x1b[0m", fmt.Sprintf(s, args...))
+}
+
 // printStateandPrompt prints the game.State and prompts to continue.
 func printStateAndPrompt(w io.ReadWriter, s *game.State) {
 	printState(w, s)
@@ -291,7 +619,16 @@ func printState(w io.ReadWriter, s *game.State) {
 	fmt.Fprintln(w, title)
 	fmt.Fprintln(w, board(s))
 	fmt.Fprintln(w)
-	fmt.Fprintln(w, legend())
+	fmt.Fprintln(w, legend(s))
+	if log := combatLog(s); log != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, log)
+	}
+}
+
+// printResult prints how the game ended.
+func printResult(w io.ReadWriter, s *game.State) {
+	fmt.Fprintln(w, result(s))
 }
 
 // printPrompt prints a prompt for the current game.Player.
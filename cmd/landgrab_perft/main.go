@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jwowillo/landgrab/game"
+	"github.com/jwowillo/landgrab/notation"
+)
+
+func main() {
+	if depth < 1 {
+		fmt.Println("depth must be positive")
+		os.Exit(1)
+	}
+	if players < 2 || players > 4 {
+		fmt.Println("players must be from 2 to 4")
+		os.Exit(1)
+	}
+	var err error
+	rules := game.StandardRules
+	if mapPath != "" {
+		rules, err = game.ReadMapFile(mapPath)
+	}
+	if err != nil {
+		fmt.Println("invalid map:", err)
+		os.Exit(1)
+	}
+	if width > 0 || height > 0 {
+		if width <= 0 || height <= 0 || mapPath != "" {
+			fmt.Println("width and height must both be positive " +
+				"and can't be used with a map")
+			os.Exit(1)
+		}
+		rules = rules.WithBoardSize(width, height)
+	}
+	rules = rules.WithPlayerCount(players)
+	rules, err = notation.WithRoster(rules, roster)
+	if err != nil {
+		fmt.Println("invalid roster:", err)
+		os.Exit(1)
+	}
+	rules, err = notation.WithPattern(rules, pattern)
+	if err != nil {
+		fmt.Println("invalid pattern:", err)
+		os.Exit(1)
+	}
+	if err := game.ValidateRules(rules); err != nil {
+		fmt.Println("invalid rules:", err)
+		os.Exit(1)
+	}
+	s := game.NewState(rules, nil, nil)
+	previous := 1
+	for d := 1; d <= depth; d++ {
+		n := game.Perft(s, d)
+		fmt.Println("Depth", d, "Nodes:", n)
+		if previous > 0 {
+			fmt.Printf(
+				"Depth %d Branching Factor: %.2f\n",
+				d, float64(n)/float64(previous),
+			)
+		}
+		previous = n
+	}
+}
+
+var (
+	depth   int
+	players int
+	width   int
+	height  int
+	mapPath string
+	roster  string
+	pattern string
+)
+
+func init() {
+	flag.IntVar(&depth, "depth", 2, "plays deep to count positions")
+	flag.IntVar(&players, "players", 2, "number of players from 2 to 4")
+	flag.IntVar(&width, "width", 0, "board width if not the standard")
+	flag.IntVar(&height, "height", 0, "board height if not the standard")
+	flag.StringVar(&mapPath, "map", "", "file with the map to count on")
+	flag.StringVar(
+		&roster, "roster", "",
+		"comma-separated classes of each player's pieces",
+	)
+	flag.StringVar(
+		&pattern, "pattern", "",
+		"movement pattern of pieces if not the standard",
+	)
+	flag.Parse()
+}
@@ -1,36 +1,103 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
 
 	"github.com/jwowillo/landgrab/arena"
 	"github.com/jwowillo/landgrab/game"
+	"github.com/jwowillo/landgrab/notation"
 	"github.com/jwowillo/landgrab/player"
 )
 
 func main() {
-	p1 := buildPlayer(player1, player.Factory)
-	p2 := buildPlayer(player2, player.Factory)
-	if p1 == nil || p2 == nil {
-		fmt.Println("invalid players chosen")
-		os.Exit(1)
+	names := []string{player1, player2}
+	if player3 != "" {
+		names = append(names, player3)
+	}
+	if player4 != "" {
+		if player3 == "" {
+			fmt.Println("player4 can't be chosen without player3")
+			os.Exit(1)
+		}
+		names = append(names, player4)
+	}
+	var ps []game.Player
+	for _, name := range names {
+		p := buildPlayer(name, player.Factory)
+		if p == nil {
+			fmt.Println("invalid players chosen")
+			os.Exit(1)
+		}
+		ps = append(ps, p)
 	}
 	if n < 0 {
 		fmt.Println("n must be non-negative")
 		os.Exit(1)
 	}
-	r := arena.Run(game.StandardRules, p1, p2, n)
-	fmt.Println("Player 1 Wins:", r.Player1Wins)
-	fmt.Println("Player 1 Average Pieces:", r.Player1AveragePieces)
-	fmt.Println("Player 1 Average Life:", r.Player1AverageLife)
-	fmt.Println("Player 1 Average Damage:", r.Player1AverageDamage)
-	fmt.Println("Player 2 Wins:", r.Player2Wins)
-	fmt.Println("Player 2 Average Pieces:", r.Player2AveragePieces)
-	fmt.Println("Player 2 Average Life:", r.Player2AverageLife)
-	fmt.Println("Player 2 Average Damage:", r.Player2AverageDamage)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	var err error
+	rules := game.StandardRules
+	if mapPath != "" {
+		rules, err = game.ReadMapFile(mapPath)
+	}
+	if err != nil {
+		fmt.Println("invalid map:", err)
+		os.Exit(1)
+	}
+	if width > 0 || height > 0 {
+		if width <= 0 || height <= 0 || mapPath != "" {
+			fmt.Println("width and height must both be positive " +
+				"and can't be used with a map")
+			os.Exit(1)
+		}
+		rules = rules.WithBoardSize(width, height)
+	}
+	rules = rules.WithPlayerCount(len(ps))
+	rules, err = notation.WithRoster(rules, roster)
+	if err != nil {
+		fmt.Println("invalid roster:", err)
+		os.Exit(1)
+	}
+	rules, err = notation.WithPattern(rules, pattern)
+	if err != nil {
+		fmt.Println("invalid pattern:", err)
+		os.Exit(1)
+	}
+	if simultaneous {
+		rules = rules.WithPlayMode(game.SimultaneousPlays)
+	}
+	if vision > 0 {
+		rules = rules.WithVision(vision)
+	}
+	if deploy > 0 {
+		rules = rules.WithHomeZones(game.SideZones(rules, deploy))
+	}
+	if err := game.ValidateRules(rules); err != nil {
+		fmt.Println("invalid rules:", err)
+		os.Exit(1)
+	}
+	r, err := arena.RunPlayersContext(ctx, rules, ps, n)
+	if err != nil {
+		fmt.Println("aborted, only finished games are included")
+	}
+	for i, p := range r.Players {
+		name := fmt.Sprintf("Player %d", i+1)
+		fmt.Println(name, "Wins:", p.Wins)
+		fmt.Println(name, "Average Pieces:", p.AveragePieces)
+		fmt.Println(name, "Average Life:", p.AverageLife)
+		fmt.Println(name, "Average Damage:", p.AverageDamage)
+		fmt.Println(name, "Timeouts:", p.Timeouts)
+		if len(r.Players) > 2 {
+			fmt.Println(name, "Average Placing:", p.AveragePlacing)
+		}
+	}
+	fmt.Println("Draws:", r.Draws)
 	fmt.Println("Average Turns:", r.AverageTurns)
 }
 
@@ -54,12 +121,48 @@ func buildPlayer(name string, factory *game.PlayerFactory) game.DescribedPlayer
 var (
 	player1 string
 	player2 string
+	player3 string
+	player4 string
 	n       int
+	width   int
+	height  int
+	mapPath string
+	deploy  int
+	roster  string
+	pattern string
+	vision  int
+
+	simultaneous bool
 )
 
 func init() {
 	flag.StringVar(&player1, "player1", "", "choice for player 2")
 	flag.StringVar(&player2, "player2", "", "choice for player 2")
+	flag.StringVar(&player3, "player3", "", "optional choice for player 3")
+	flag.StringVar(&player4, "player4", "", "optional choice for player 4")
 	flag.IntVar(&n, "n", -1, "times to play")
+	flag.IntVar(&width, "width", 0, "board width if not the standard")
+	flag.IntVar(&height, "height", 0, "board height if not the standard")
+	flag.StringVar(&mapPath, "map", "", "file with the map to play on")
+	flag.StringVar(
+		&roster, "roster", "",
+		"comma-separated classes of each player's pieces",
+	)
+	flag.StringVar(
+		&pattern, "pattern", "",
+		"movement pattern of pieces if not the standard",
+	)
+	flag.IntVar(
+		&vision, "vision", 0,
+		"cells each piece sees in games with fog of war if positive",
+	)
+	flag.IntVar(
+		&deploy, "deploy", 0,
+		"rows or columns along each side players deploy to if positive",
+	)
+	flag.BoolVar(
+		&simultaneous, "simultaneous", false,
+		"players make plays at the same time if true",
+	)
 	flag.Parse()
 }
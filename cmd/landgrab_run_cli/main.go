@@ -9,15 +9,62 @@ import (
 
 	"github.com/jwowillo/landgrab/cli"
 	"github.com/jwowillo/landgrab/game"
+	"github.com/jwowillo/landgrab/notation"
 	"github.com/jwowillo/landgrab/player"
 )
 
 func main() {
 	w := bufio.NewWriter(os.Stdout)
 	app := cli.New(os.Stdin, w, func() { w.Flush() }, shouldWait)
-	p1 := buildPlayer(w, player1, player.Factory)
-	p2 := buildPlayer(w, player2, player.Factory)
-	app.Run(player.Factory, p1, p2)
+	var err error
+	rules := game.StandardRules
+	if mapPath != "" {
+		rules, err = game.ReadMapFile(mapPath)
+	}
+	if err != nil {
+		fmt.Fprintln(w, "invalid map:", err)
+		w.Flush()
+		os.Exit(1)
+	}
+	if players < 2 || players > game.MaxPlayerCount {
+		fmt.Fprintln(
+			w,
+			"players must be between 2 and", game.MaxPlayerCount,
+		)
+		w.Flush()
+		os.Exit(1)
+	}
+	rules, err = notation.WithRoster(rules.WithPlayerCount(players), roster)
+	if err != nil {
+		fmt.Fprintln(w, "invalid roster:", err)
+		w.Flush()
+		os.Exit(1)
+	}
+	rules, err = notation.WithPattern(rules, pattern)
+	if err != nil {
+		fmt.Fprintln(w, "invalid pattern:", err)
+		w.Flush()
+		os.Exit(1)
+	}
+	rules = rules.WithVision(vision).WithTerritoryControl(territory)
+	if err := game.ValidateRules(rules); err != nil {
+		fmt.Fprintln(w, "invalid rules:", err)
+		w.Flush()
+		os.Exit(1)
+	}
+	app.SetRules(rules)
+	if position != "" {
+		if err := app.SetPosition(position); err != nil {
+			fmt.Fprintln(w, "invalid position:", err)
+			w.Flush()
+			os.Exit(1)
+		}
+	}
+	var ps []game.DescribedPlayer
+	for _, name := range []string{player1, player2, player3, player4} {
+		ps = append(ps, buildPlayer(w, name, player.Factory))
+	}
+	app.RunPlayers(player.Factory, ps)
 }
 
 func buildPlayer(w *bufio.Writer, name string, factory *game.PlayerFactory) game.DescribedPlayer {
@@ -40,6 +87,14 @@ var (
 	// pressed to continue.
 	shouldWait       bool
 	player1, player2 string
+	player3, player4 string
+	players          int
+	mapPath          string
+	roster           string
+	pattern          string
+	vision           int
+	territory        int
+	position         string
 )
 
 // init parses command-line flags.
@@ -47,5 +102,29 @@ func init() {
 	flag.BoolVar(&shouldWait, "wait", true, "waits for enter if true")
 	flag.StringVar(&player1, "player1", "", "choice for player 1")
 	flag.StringVar(&player2, "player2", "", "choice for player 2")
+	flag.StringVar(&player3, "player3", "", "choice for player 3")
+	flag.StringVar(&player4, "player4", "", "choice for player 4")
+	flag.IntVar(&players, "players", 2, "number of players from 2 to 4")
+	flag.StringVar(&mapPath, "map", "", "file with the map to play on")
+	flag.StringVar(
+		&roster, "roster", "",
+		"comma-separated classes of each player's pieces",
+	)
+	flag.StringVar(
+		&pattern, "pattern", "",
+		"movement pattern of pieces if not the standard",
+	)
+	flag.IntVar(
+		&vision, "vision", 0,
+		"cells each piece sees in games with fog of war if positive",
+	)
+	flag.IntVar(
+		&territory, "territory", 0,
+		"percent of the board owned to win by territory if positive",
+	)
+	flag.StringVar(
+		&position, "position", "",
+		"position to start at which overrides the other rules flags",
+	)
 	flag.Parse()
 }
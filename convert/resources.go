@@ -15,6 +15,7 @@ func (p JSONPlay) Description() string {
 // JSONMove ...
 type JSONMove struct {
 	Direction string    `json:"direction"`
+	Offset    [2]int    `json:"offset"`
 	Piece     JSONPiece `json:"piece"`
 }
 
@@ -41,6 +42,7 @@ type JSONPiece struct {
 	Player string       `json:"player"`
 	Life   int          `json:"life"`
 	Damage int          `json:"damage"`
+	Class  string       `json:"class,omitempty"`
 	Cell   [2]int       `json:"cell"`
 }
 
@@ -49,15 +51,59 @@ func (s JSONPiece) Description() string {
 	return "piece on the board"
 }
 
+// JSONEvent ...
+//
+// Cells and pieces which aren't part of the event are left out.
+type JSONEvent struct {
+	Kind   string       `json:"kind"`
+	Piece  game.PieceID `json:"piece"`
+	Target game.PieceID `json:"target,omitempty"`
+	From   [2]int       `json:"from"`
+	To     *[2]int      `json:"to,omitempty"`
+	Life   int          `json:"life,omitempty"`
+	Damage int          `json:"damage,omitempty"`
+}
+
+// Description ...
+func (e JSONEvent) Description() string {
+	return "what happened while a play was made"
+}
+
 // JSONRules ...
 type JSONRules struct {
-	TimerDuration  int `json:"timerDuration"`
-	PieceCount     int `json:"pieceCount"`
-	BoardSize      int `json:"boardSize"`
-	Life           int `json:"life"`
-	Damage         int `json:"damage"`
-	LifeIncrease   int `json:"lifeIncrease"`
-	DamageIncrease int `json:"damageIncrease"`
+	TimerDuration   int           `json:"timerDuration"`
+	PlayerCount     int           `json:"playerCount,omitempty"`
+	PieceCount      int           `json:"pieceCount"`
+	BoardSize       int           `json:"boardSize"`
+	BoardWidth      int           `json:"boardWidth,omitempty"`
+	BoardHeight     int           `json:"boardHeight,omitempty"`
+	Life            int           `json:"life"`
+	Damage          int           `json:"damage"`
+	LifeIncrease    int           `json:"lifeIncrease"`
+	DamageIncrease  int           `json:"damageIncrease"`
+	MaxTurns        int           `json:"maxTurns,omitempty"`
+	RepetitionLimit int           `json:"repetitionLimit,omitempty"`
+	TimeoutPolicy   string        `json:"timeoutPolicy,omitempty"`
+	PlayMode        string        `json:"playMode,omitempty"`
+	Blocked         [][2]int      `json:"blocked,omitempty"`
+	Layout          [][][2]int    `json:"layout,omitempty"`
+	HomeZones       [][][2]int    `json:"homeZones,omitempty"`
+	Rosters         [][]JSONClass `json:"rosters,omitempty"`
+	Pattern         *JSONPattern  `json:"pattern,omitempty"`
+	Vision          int           `json:"vision,omitempty"`
+	Territory       int           `json:"territoryControl,omitempty"`
+}
+
+// JSONPattern ...
+type JSONPattern struct {
+	Name    string   `json:"name"`
+	Slides  bool     `json:"slides,omitempty"`
+	Offsets [][2]int `json:"offsets"`
+}
+
+// Description ...
+func (p JSONPattern) Description() string {
+	return "offsets pieces can move by"
 }
 
 // Description ...
@@ -65,17 +111,80 @@ func (r JSONRules) Description() string {
 	return "defines variable parts of a game"
 }
 
+// JSONClass ...
+type JSONClass struct {
+	Name           string `json:"name"`
+	Life           int    `json:"life"`
+	Damage         int    `json:"damage"`
+	LifeIncrease   int    `json:"lifeIncrease"`
+	DamageIncrease int    `json:"damageIncrease"`
+	Moves          int    `json:"moves"`
+}
+
+// Description ...
+func (c JSONClass) Description() string {
+	return "stats shared by pieces of the same kind"
+}
+
 // JSONState ...
 type JSONState struct {
-	CurrentPlayer string      `json:"currentPlayer"`
-	Winner        string      `json:"winner,omitempty"`
-	Rules         JSONRules   `json:"rules"`
-	Player1       JSONPlayer  `json:"player1"`
-	Player2       JSONPlayer  `json:"player2"`
-	Pieces        []JSONPiece `json:"pieces"`
+	CurrentPlayer string         `json:"currentPlayer"`
+	Winner        string         `json:"winner,omitempty"`
+	Draw          bool           `json:"draw,omitempty"`
+	TimedOut      string         `json:"timedOut,omitempty"`
+	Forfeited     string         `json:"forfeited,omitempty"`
+	Eliminated    []string       `json:"eliminated,omitempty"`
+	Turn          int            `json:"turn"`
+	Deploying     bool           `json:"deploying,omitempty"`
+	Viewer        string         `json:"viewer,omitempty"`
+	Hidden        map[string]int `json:"hidden,omitempty"`
+	Owners        [][][2]int     `json:"owners,omitempty"`
+	Territory     map[string]int `json:"territory,omitempty"`
+	Rules         JSONRules      `json:"rules"`
+	Player1       JSONPlayer     `json:"player1"`
+	Player2       JSONPlayer     `json:"player2"`
+	Player3       *JSONPlayer    `json:"player3,omitempty"`
+	Player4       *JSONPlayer    `json:"player4,omitempty"`
+	Pieces        []JSONPiece    `json:"pieces"`
+	Events        []JSONEvent    `json:"events,omitempty"`
 }
 
 // Description ...
 func (s JSONState) Description() string {
 	return "state of the game"
 }
+
+// Players in the JSONState in order with zero-value JSONPlayers for players
+// left out.
+func (s JSONState) Players() []JSONPlayer {
+	ps := []JSONPlayer{s.Player1, s.Player2, {}, {}}
+	if s.Player3 != nil {
+		ps[2] = *s.Player3
+	}
+	if s.Player4 != nil {
+		ps[3] = *s.Player4
+	}
+	return ps
+}
+
+// SetPlayers in the JSONState in order.
+//
+// Players 1 and 2 are left as zero-values and players 3 and 4 are left out if
+// not given.
+func (s *JSONState) SetPlayers(ps []JSONPlayer) {
+	s.Player1, s.Player2 = JSONPlayer{}, JSONPlayer{}
+	s.Player3, s.Player4 = nil, nil
+	for i := range ps {
+		p := ps[i]
+		switch i {
+		case 0:
+			s.Player1 = p
+		case 1:
+			s.Player2 = p
+		case 2:
+			s.Player3 = &p
+		case 3:
+			s.Player4 = &p
+		}
+	}
+}
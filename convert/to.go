@@ -2,6 +2,7 @@ package convert
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/jwowillo/landgrab/game"
@@ -38,10 +39,29 @@ func JSONToPlay(bs []byte) (game.Play, error) {
 	return JSONPlayToPlay(play), err
 }
 
+// JSONToPlays converts a JSON object from player strings like "player 1" to
+// JSONPlays into game.Plays for each game.PlayerID.
+//
+// Unknown players are left out.
+func JSONToPlays(bs []byte) (map[game.PlayerID]game.Play, error) {
+	raw := make(map[string]JSONPlay)
+	if err := json.Unmarshal(bs, &raw); err != nil {
+		return nil, err
+	}
+	plays := make(map[game.PlayerID]game.Play)
+	for x, p := range raw {
+		if id := stringToPlayerID(x); id != game.NoPlayer {
+			plays[id] = JSONPlayToPlay(p)
+		}
+	}
+	return plays, nil
+}
+
 // MoveToJSONMove ...
 func MoveToJSONMove(m game.Move, s *game.State) JSONMove {
 	return JSONMove{
 		Direction: m.Direction().String(),
+		Offset:    [2]int{m.Offset().Rows(), m.Offset().Columns()},
 		Piece:     PieceToJSONPiece(s, m.Piece()),
 	}
 }
@@ -54,6 +74,8 @@ func JSONToJSONMove(bs []byte) (JSONMove, error) {
 }
 
 // JSONMoveToMove ...
+//
+// The offset is used if the direction is left out.
 func JSONMoveToMove(m JSONMove) game.Move {
 	var d game.Direction
 	switch m.Direction {
@@ -73,6 +95,11 @@ func JSONMoveToMove(m JSONMove) game.Move {
 		d = game.West
 	case "north-west":
 		d = game.NorthWest
+	case "":
+		return game.NewOffsetMove(
+			JSONPieceToPiece(m.Piece),
+			game.NewOffset(m.Offset[0], m.Offset[1]),
+		)
 	}
 	return game.NewMove(JSONPieceToPiece(m.Piece), d)
 }
@@ -89,6 +116,9 @@ func PieceToJSONPiece(s *game.State, p game.Piece) JSONPiece {
 	raw.ID = p.ID()
 	raw.Life = p.Life()
 	raw.Damage = p.Damage()
+	if p.Class() != game.StandardClass {
+		raw.Class = p.Class().Name()
+	}
 	c := s.CellForPiece(p)
 	raw.Cell = [2]int{c.Row(), c.Column()}
 	raw.Player = s.PlayerForPiece(p).String()
@@ -121,6 +151,10 @@ func stringToPlayerID(x string) game.PlayerID {
 		return game.Player1
 	case "player 2":
 		return game.Player2
+	case "player 3":
+		return game.Player3
+	case "player 4":
+		return game.Player4
 	}
 	return game.NoPlayer
 }
@@ -131,19 +165,96 @@ func StateToJSONState(s *game.State) JSONState {
 	if s.Winner() != game.NoPlayer {
 		raw.Winner = s.Winner().String()
 	}
+	raw.Draw = s.IsDraw()
+	if s.TimedOut() != game.NoPlayer {
+		raw.TimedOut = s.TimedOut().String()
+	}
+	if s.Forfeited() != game.NoPlayer {
+		raw.Forfeited = s.Forfeited().String()
+	}
+	for _, id := range s.Eliminated() {
+		raw.Eliminated = append(raw.Eliminated, id.String())
+	}
+	raw.Turn = s.Turn()
+	raw.Deploying = s.IsDeploying()
+	if s.Viewer() != game.NoPlayer {
+		raw.Viewer = s.Viewer().String()
+		for _, id := range s.Rules().PlayerIDs() {
+			if n := s.HiddenPieces(id); n > 0 {
+				if raw.Hidden == nil {
+					raw.Hidden = make(map[string]int)
+				}
+				raw.Hidden[id.String()] = n
+			}
+		}
+	}
+	if s.Rules().TerritoryControl() > 0 {
+		raw.Owners, raw.Territory = ownersToJSONOwners(s)
+	}
 	raw.CurrentPlayer = s.CurrentPlayer().String()
-	p1, p1Ok := s.Player1().(game.DescribedPlayer)
-	p2, p2Ok := s.Player2().(game.DescribedPlayer)
-	if !p1Ok || !p2Ok {
-		p1 = nil
-		p2 = nil
-	}
-	raw.Player1 = PlayerToJSONPlayer(p1)
-	raw.Player2 = PlayerToJSONPlayer(p2)
+	var players []JSONPlayer
+	for _, id := range s.Rules().PlayerIDs() {
+		p, ok := s.Player(id).(game.DescribedPlayer)
+		if !ok {
+			players = nil
+			break
+		}
+		players = append(players, PlayerToJSONPlayer(p))
+	}
+	raw.SetPlayers(players)
 	raw.Rules = RulesToJSONRules(s.Rules())
 	for _, p := range s.Pieces() {
 		raw.Pieces = append(raw.Pieces, PieceToJSONPiece(s, p))
 	}
+	for _, e := range s.Events() {
+		raw.Events = append(raw.Events, EventToJSONEvent(e))
+	}
+	return raw
+}
+
+// ownersToJSONOwners lists the cells each player owns in the game.State along
+// with how many each player owns.
+func ownersToJSONOwners(s *game.State) ([][][2]int, map[string]int) {
+	cells := make(map[game.PlayerID][]game.Cell)
+	for i := 0; i < s.Rules().BoardHeight(); i++ {
+		for j := 0; j < s.Rules().BoardWidth(); j++ {
+			c := game.NewCell(i, j)
+			if id := s.Owner(c); id != game.NoPlayer {
+				cells[id] = append(cells[id], c)
+			}
+		}
+	}
+	territory := make(map[string]int)
+	for _, id := range s.Rules().PlayerIDs() {
+		territory[id.String()] = s.Territory(id)
+	}
+	return layoutToJSONLayout(game.NewLayout(cells)), territory
+}
+
+// jsonOwnersToOwners is the inverse of ownersToJSONOwners.
+func jsonOwnersToOwners(raw [][][2]int) map[game.Cell]game.PlayerID {
+	owners := make(map[game.Cell]game.PlayerID)
+	for i, cs := range raw {
+		for _, c := range cs {
+			owners[game.NewCell(c[0], c[1])] = game.PlayerID(i + 1)
+		}
+	}
+	return owners
+}
+
+// EventToJSONEvent ...
+func EventToJSONEvent(e game.Event) JSONEvent {
+	raw := JSONEvent{
+		Kind:   e.Kind.String(),
+		Piece:  e.Piece,
+		Target: e.Target,
+		From:   [2]int{e.From.Row(), e.From.Column()},
+		Life:   e.Life,
+		Damage: e.Damage,
+	}
+	if e.To != e.From {
+		raw.To = &[2]int{e.To.Row(), e.To.Column()}
+	}
 	return raw
 }
 
@@ -155,27 +266,70 @@ func JSONToJSONState(bs []byte) (JSONState, error) {
 }
 
 // JSONStateToState ...
-func JSONStateToState(s JSONState, factory *game.PlayerFactory) *game.State {
-	p1 := JSONPlayerToPlayer(s.Player1, factory)
-	p2 := JSONPlayerToPlayer(s.Player2, factory)
+//
+// A game.PieceError is returned if pieces share a cell and the error from
+// game.ValidateInfo is returned if the rest of the JSONState is invalid.
+func JSONStateToState(
+	s JSONState,
+	factory *game.PlayerFactory,
+) (*game.State, error) {
+	rules := JSONRulesToRules(s.Rules)
+	var players []game.Player
+	for _, p := range s.Players()[:rules.PlayerCount()] {
+		players = append(players, JSONPlayerToPlayer(p, factory))
+	}
 	Pieces := make(map[game.Cell]game.Piece)
 	for _, rawPiece := range s.Pieces {
 		Piece := JSONPieceToPiece(rawPiece)
-		Pieces[game.NewCell(rawPiece.Cell[0], rawPiece.Cell[1])] = Piece
+		if c, ok := rules.Class(rawPiece.Class); ok {
+			Piece = game.NewPieceWithClass(
+				Piece.ID(), Piece.Life(), Piece.Damage(), c,
+			)
+		}
+		c := game.NewCell(rawPiece.Cell[0], rawPiece.Cell[1])
+		if other, ok := Pieces[c]; ok {
+			return nil, &game.PieceError{
+				Piece: Piece.ID(),
+				Cell:  c,
+				Reason: fmt.Sprintf(
+					"shares its cell with piece %d",
+					other.ID(),
+				),
+			}
+		}
+		Pieces[c] = Piece
 	}
-	return game.NewStateFromInfo(
-		JSONRulesToRules(s.Rules),
-		stringToPlayerID(s.CurrentPlayer),
-		p1, p2,
+	current := stringToPlayerID(s.CurrentPlayer)
+	if err := game.ValidateInfo(rules, current, Pieces); err != nil {
+		return nil, err
+	}
+	state := game.NewStateFromInfoWithPlayers(
+		rules,
+		current,
+		players,
 		Pieces,
-	)
+	).WithTurn(s.Turn).WithDeploying(s.Deploying)
+	if len(s.Owners) != 0 {
+		state = state.WithOwners(jsonOwnersToOwners(s.Owners))
+	}
+	if s.Viewer != "" {
+		hidden := make(map[game.PlayerID]int, len(s.Hidden))
+		for id, n := range s.Hidden {
+			hidden[stringToPlayerID(id)] = n
+		}
+		viewer := stringToPlayerID(s.Viewer)
+		state = state.WithHiddenPieces(viewer, hidden)
+	}
+	return state, nil
 }
 
 // JSONToState ...
 func JSONToState(bs []byte, factory *game.PlayerFactory) (*game.State, error) {
 	rs, err := JSONToJSONState(bs)
-	s := JSONStateToState(rs, factory)
-	return s, err
+	if err != nil {
+		return nil, err
+	}
+	return JSONStateToState(rs, factory)
 }
 
 // PlayerToJSONPlayer ...
@@ -214,17 +368,58 @@ func JSONToPlayer(
 
 // RulesToJSONRules ...
 func RulesToJSONRules(r game.Rules) JSONRules {
+	var blocked [][2]int
+	for _, c := range r.Terrain().Blocked() {
+		blocked = append(blocked, [2]int{c.Row(), c.Column()})
+	}
 	return JSONRules{
-		TimerDuration:  int(r.TimerDuration() / time.Second),
-		PieceCount:     r.PieceCount(),
-		BoardSize:      r.BoardSize(),
-		Life:           r.Life(),
-		Damage:         r.Damage(),
-		LifeIncrease:   r.LifeIncrease(),
-		DamageIncrease: r.DamageIncrease(),
+		TimerDuration:   int(r.TimerDuration() / time.Second),
+		PlayerCount:     r.PlayerCount(),
+		PieceCount:      r.PieceCount(),
+		BoardSize:       r.BoardSize(),
+		BoardWidth:      r.BoardWidth(),
+		BoardHeight:     r.BoardHeight(),
+		Life:            r.Life(),
+		Damage:          r.Damage(),
+		LifeIncrease:    r.LifeIncrease(),
+		DamageIncrease:  r.DamageIncrease(),
+		MaxTurns:        r.MaxTurns(),
+		RepetitionLimit: r.RepetitionLimit(),
+		TimeoutPolicy:   r.TimeoutPolicy().String(),
+		PlayMode:        r.PlayMode().String(),
+		Blocked:         blocked,
+		Layout:          layoutToJSONLayout(r.Layout()),
+		HomeZones:       layoutToJSONLayout(r.HomeZones()),
+		Rosters:         rostersToJSONRosters(r),
+		Pattern:         patternToJSONPattern(r.Pattern()),
+		Vision:          r.Vision(),
+		Territory:       r.TerritoryControl(),
 	}
 }
 
+// patternToJSONPattern ...
+//
+// nil is returned for game.KingPattern since it's the default.
+func patternToJSONPattern(p *game.Pattern) *JSONPattern {
+	if p == game.KingPattern {
+		return nil
+	}
+	raw := &JSONPattern{Name: p.Name(), Slides: p.Slides()}
+	for _, o := range p.Offsets() {
+		raw.Offsets = append(raw.Offsets, [2]int{o.Rows(), o.Columns()})
+	}
+	return raw
+}
+
+// jsonPatternToPattern is the inverse of patternToJSONPattern.
+func jsonPatternToPattern(raw JSONPattern) *game.Pattern {
+	offsets := make([]game.Offset, len(raw.Offsets))
+	for i, o := range raw.Offsets {
+		offsets[i] = game.NewOffset(o[0], o[1])
+	}
+	return game.NewPattern(raw.Name, raw.Slides, offsets...)
+}
+
 // JSONToJSONRules ...
 func JSONToJSONRules(bs []byte) (JSONRules, error) {
 	r := JSONRules{}
@@ -233,8 +428,11 @@ func JSONToJSONRules(bs []byte) (JSONRules, error) {
 }
 
 // JSONRulesToRules ...
+//
+// Player counts, board sizes and draw conditions which are left out keep the
+// defaults from game.NewRules.
 func JSONRulesToRules(r JSONRules) game.Rules {
-	return game.NewRules(
+	rules := game.NewRules(
 		time.Duration(r.TimerDuration)*time.Second,
 		r.PieceCount,
 		r.Life,
@@ -242,6 +440,129 @@ func JSONRulesToRules(r JSONRules) game.Rules {
 		r.LifeIncrease,
 		r.DamageIncrease,
 	)
+	if r.PlayerCount != 0 {
+		rules = rules.WithPlayerCount(r.PlayerCount)
+	}
+	if r.BoardWidth != 0 && r.BoardHeight != 0 {
+		rules = rules.WithBoardSize(r.BoardWidth, r.BoardHeight)
+	}
+	if r.MaxTurns != 0 {
+		rules = rules.WithMaxTurns(r.MaxTurns)
+	}
+	if r.RepetitionLimit != 0 {
+		rules = rules.WithRepetitionLimit(r.RepetitionLimit)
+	}
+	if r.TimeoutPolicy == game.ForfeitOnTimeout.String() {
+		rules = rules.WithTimeoutPolicy(game.ForfeitOnTimeout)
+	}
+	if r.PlayMode == game.SimultaneousPlays.String() {
+		rules = rules.WithPlayMode(game.SimultaneousPlays)
+	}
+	if len(r.Blocked) != 0 {
+		blocked := make([]game.Cell, len(r.Blocked))
+		for i, c := range r.Blocked {
+			blocked[i] = game.NewCell(c[0], c[1])
+		}
+		rules = rules.WithTerrain(game.NewTerrain(blocked...))
+	}
+	if len(r.Layout) != 0 {
+		rules = rules.WithLayout(jsonLayoutToLayout(r.Layout))
+	}
+	if len(r.HomeZones) != 0 {
+		rules = rules.WithHomeZones(jsonLayoutToLayout(r.HomeZones))
+	}
+	if r.Vision != 0 {
+		rules = rules.WithVision(r.Vision)
+	}
+	if r.Territory != 0 {
+		rules = rules.WithTerritoryControl(r.Territory)
+	}
+	if r.Pattern != nil {
+		rules = rules.WithPattern(jsonPatternToPattern(*r.Pattern))
+	}
+	for i, roster := range r.Rosters {
+		var classes []game.Class
+		for _, c := range roster {
+			classes = append(classes, JSONClassToClass(c))
+		}
+		rules = rules.WithRoster(game.PlayerID(i+1), classes...)
+	}
+	return rules
+}
+
+// rostersToJSONRosters lists the classes in each player's roster in order.
+//
+// nil is returned if no player has a roster.
+func rostersToJSONRosters(r game.Rules) [][]JSONClass {
+	raw := make([][]JSONClass, game.MaxPlayerCount)
+	empty := true
+	for i := range raw {
+		for _, c := range r.Roster(game.PlayerID(i + 1)) {
+			raw[i] = append(raw[i], ClassToJSONClass(c))
+			empty = false
+		}
+	}
+	if empty {
+		return nil
+	}
+	return raw
+}
+
+// ClassToJSONClass ...
+func ClassToJSONClass(c game.Class) JSONClass {
+	return JSONClass{
+		Name:           c.Name(),
+		Life:           c.Life(),
+		Damage:         c.Damage(),
+		LifeIncrease:   c.LifeIncrease(),
+		DamageIncrease: c.DamageIncrease(),
+		Moves:          c.Moves(),
+	}
+}
+
+// JSONClassToClass ...
+//
+// The class named "standard" is game.StandardClass no matter its stats.
+func JSONClassToClass(c JSONClass) game.Class {
+	if c.Name == game.StandardClass.Name() {
+		return game.StandardClass
+	}
+	return game.NewClass(
+		c.Name,
+		c.Life,
+		c.Damage,
+		c.LifeIncrease,
+		c.DamageIncrease,
+		c.Moves,
+	)
+}
+
+// layoutToJSONLayout lists the cells assigned to each player in order.
+//
+// nil is returned for nil game.Layouts.
+func layoutToJSONLayout(l *game.Layout) [][][2]int {
+	if l == nil {
+		return nil
+	}
+	raw := make([][][2]int, game.MaxPlayerCount)
+	for i := range raw {
+		for _, c := range l.Cells(game.PlayerID(i + 1)) {
+			raw[i] = append(raw[i], [2]int{c.Row(), c.Column()})
+		}
+	}
+	return raw
+}
+
+// jsonLayoutToLayout is the inverse of layoutToJSONLayout.
+func jsonLayoutToLayout(raw [][][2]int) *game.Layout {
+	cells := make(map[game.PlayerID][]game.Cell)
+	for i, cs := range raw {
+		for _, c := range cs {
+			id := game.PlayerID(i + 1)
+			cells[id] = append(cells[id], game.NewCell(c[0], c[1]))
+		}
+	}
+	return game.NewLayout(cells)
 }
 
 // JSONToRules ...
@@ -0,0 +1,253 @@
+package game
+
+// pieceStride is the number of values each Piece takes in a board.
+const pieceStride = 5
+
+// board is a compact mapping of PieceIDs to Pieces, Pieces to Cells, and Cells
+// to PieceIDs packed into a single slice so copying it is cheap.
+//
+// Each Piece takes pieceStride values in order of PieceID holding its PieceID,
+// life, damage, row, and column. Removed Pieces have NoPieceID and Pieces which
+// aren't in a Cell have NoCell. The PieceID in each Cell of the grid follows in
+// row order, and then the PlayerID of the owner of each Cell in row order if
+// the board has owners. Classes can't change, so they're kept apart in a table
+// which is shared between copies.
+//
+// Every value set is recorded in the journal if it isn't nil so the changes can
+// be taken back.
+type board struct {
+	width, height int
+	pieceCount    int
+	classes       []Class
+	data          []int32
+	journal       *[]boardChange
+}
+
+// boardChange is a value at an index of a board's data being set, recording
+// the old value.
+type boardChange struct {
+	index int
+	old   int32
+}
+
+// newBoard with a grid of the given width and height where each of the given
+// number of Players has the given amount of Pieces.
+//
+// The board has owners for its Cells iff owners is true.
+func newBoard(w, h, pc, players int, owners bool) board {
+	n := pc * players
+	size := n*pieceStride + w*h
+	if owners {
+		size += w * h
+	}
+	b := board{
+		width:      w,
+		height:     h,
+		pieceCount: pc,
+		classes:    make([]Class, n),
+		data:       make([]int32, size),
+	}
+	for i := 0; i < n; i++ {
+		b.data[i*pieceStride+3] = int32(NoCell.Row())
+		b.data[i*pieceStride+4] = int32(NoCell.Column())
+	}
+	return b
+}
+
+// Piece with the PieceID.
+//
+// Also return a bool that is true iff the board has the Piece.
+func (b board) Piece(pid PieceID) (Piece, bool) {
+	i := b.pieceIndex(pid)
+	if i < 0 || b.data[i] == NoPieceID {
+		return NoPiece, false
+	}
+	return Piece{
+		id:     PieceID(b.data[i]),
+		life:   int(b.data[i+1]),
+		damage: int(b.data[i+2]),
+		class:  b.classes[pid-1],
+	}, true
+}
+
+// SetPiece with the PieceID to the Piece.
+//
+// Setting NoPiece removes the Piece with the PieceID like RemovePiece.
+func (b *board) SetPiece(pid PieceID, p Piece) {
+	i := b.pieceIndex(pid)
+	if i < 0 {
+		return
+	}
+	if p == NoPiece {
+		b.RemovePiece(pid)
+		return
+	}
+	if p.Class() != b.classes[pid-1] {
+		b.classes = append([]Class{}, b.classes...)
+		b.classes[pid-1] = p.Class()
+	}
+	b.set(i, int32(p.ID()))
+	b.set(i+1, int32(p.Life()))
+	b.set(i+2, int32(p.Damage()))
+}
+
+// RemovePiece with the PieceID.
+func (b board) RemovePiece(pid PieceID) {
+	if i := b.pieceIndex(pid); i >= 0 {
+		b.set(i, NoPieceID)
+		b.set(i+1, 0)
+		b.set(i+2, 0)
+	}
+}
+
+// Cell the Piece with the PieceID is in.
+//
+// Also return a bool that is true iff the Piece is in a Cell.
+func (b board) Cell(pid PieceID) (Cell, bool) {
+	i := b.pieceIndex(pid)
+	if i < 0 {
+		return NoCell, false
+	}
+	c := NewCell(int(b.data[i+3]), int(b.data[i+4]))
+	return c, c != NoCell
+}
+
+// SetCell the Piece with the PieceID is in to the Cell.
+func (b board) SetCell(pid PieceID, c Cell) {
+	if i := b.pieceIndex(pid); i >= 0 {
+		b.set(i+3, int32(c.Row()))
+		b.set(i+4, int32(c.Column()))
+	}
+}
+
+// PieceID in the Cell.
+//
+// Also return a bool that is true iff a Piece is in the Cell.
+func (b board) PieceID(c Cell) (PieceID, bool) {
+	i := b.cellIndex(c)
+	if i < 0 {
+		return NoPieceID, false
+	}
+	pid := PieceID(b.data[i])
+	return pid, pid != NoPieceID
+}
+
+// SetPieceID in the Cell to the PieceID.
+func (b board) SetPieceID(c Cell, pid PieceID) {
+	if i := b.cellIndex(c); i >= 0 {
+		b.set(i, int32(pid))
+	}
+}
+
+// RemovePieceID in the Cell.
+func (b board) RemovePieceID(c Cell) {
+	b.SetPieceID(c, NoPieceID)
+}
+
+// Owner of the Cell or NoPlayer if it has none or the board has no owners.
+func (b board) Owner(c Cell) PlayerID {
+	i := b.ownerIndex(c)
+	if i < 0 {
+		return NoPlayer
+	}
+	return PlayerID(b.data[i])
+}
+
+// SetOwner of the Cell to the PlayerID if the board has owners.
+func (b board) SetOwner(c Cell, id PlayerID) {
+	if i := b.ownerIndex(c); i >= 0 {
+		b.set(i, int32(id))
+	}
+}
+
+// Pieces on the board in order of PieceID.
+func (b board) Pieces() []Piece {
+	return b.piecesBetween(1, len(b.classes))
+}
+
+// PlayerPieces on the board which belong to the Player with the PlayerID in
+// order of PieceID.
+func (b board) PlayerPieces(id PlayerID) []Piece {
+	start := (int(id)-1)*b.pieceCount + 1
+	if start < 1 || start > len(b.classes) {
+		return nil
+	}
+	return b.piecesBetween(start, start+b.pieceCount-1)
+}
+
+// piecesBetween returns the Pieces on the board with PieceIDs from first to
+// last or nil if there are none.
+func (b board) piecesBetween(first, last int) []Piece {
+	n := 0
+	for pid := first; pid <= last; pid++ {
+		if b.data[(pid-1)*pieceStride] != NoPieceID {
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	ps := make([]Piece, 0, n)
+	for pid := first; pid <= last; pid++ {
+		if p, ok := b.Piece(PieceID(pid)); ok {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// pieceIndex of the first value of the Piece with the PieceID or -1 if no
+// Piece can have the PieceID.
+func (b board) pieceIndex(pid PieceID) int {
+	if pid <= NoPieceID || int(pid) > len(b.classes) {
+		return -1
+	}
+	return (int(pid) - 1) * pieceStride
+}
+
+// cellIndex of the value of the Cell or -1 if the Cell isn't on the grid.
+func (b board) cellIndex(c Cell) int {
+	r, col := c.Row(), c.Column()
+	if r < 0 || r >= b.height || col < 0 || col >= b.width {
+		return -1
+	}
+	return len(b.classes)*pieceStride + b.width*r + col
+}
+
+// ownerIndex of the owner of the Cell or -1 if the Cell isn't on the grid or
+// the board has no owners.
+func (b board) ownerIndex(c Cell) int {
+	i := b.cellIndex(c)
+	cells := b.width * b.height
+	if i < 0 || len(b.data) < len(b.classes)*pieceStride+2*cells {
+		return -1
+	}
+	return i + cells
+}
+
+// set the value at the index of the data after recording the old value in the
+// journal.
+func (b board) set(i int, v int32) {
+	if b.journal != nil {
+		c := boardChange{index: i, old: b.data[i]}
+		*b.journal = append(*b.journal, c)
+	}
+	b.data[i] = v
+}
+
+// revert the changes recorded in the journal after the first n in reverse
+// order and forget them.
+func (b board) revert(n int) {
+	j := *b.journal
+	for i := len(j) - 1; i >= n; i-- {
+		b.data[j[i].index] = j[i].old
+	}
+	*b.journal = j[:n]
+}
+
+// clone the board without its journal.
+func (b board) clone() board {
+	b.data = append([]int32(nil), b.data...)
+	b.journal = nil
+	return b
+}
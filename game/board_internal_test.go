@@ -0,0 +1,245 @@
+package game
+
+import "testing"
+
+// boardSize is the width and height of the grid of board to use.
+const boardSize = 11
+
+// boardPieceCount is the number of Pieces each of the 2 Players has in a
+// board.
+const boardPieceCount = 5
+
+// BenchmarkBoardOperations benchmarks the efficiency of setting, getting, and
+// removing from a board.
+func BenchmarkBoardOperations(b *testing.B) {
+	p := NewPiece(1, 1, 1)
+	for i := 0; i < b.N; i++ {
+		m := newBoard(boardSize, boardSize, boardPieceCount, 2, false)
+		for j := 0; j < boardSize; j++ {
+			for k := 0; k < boardSize; k++ {
+				c := NewCell(j, k)
+				m.SetPiece(p.ID(), p)
+				m.SetCell(p.ID(), c)
+				m.SetPieceID(c, p.ID())
+				m.Piece(p.ID())
+				m.Cell(p.ID())
+				m.PieceID(c)
+				m.RemovePieceID(c)
+				m.RemovePiece(p.ID())
+			}
+		}
+	}
+}
+
+// BenchmarkBoardClone benchmarks the efficiency of cloning a board.
+func BenchmarkBoardClone(b *testing.B) {
+	m := newBoard(boardSize, boardSize, boardPieceCount, 2, false)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.clone()
+	}
+}
+
+// TestBoardPieces tests that Pieces can properly be set, gotten, and removed
+// from a board.
+func TestBoardPieces(t *testing.T) {
+	t.Parallel()
+	m := newBoard(boardSize, boardSize, boardPieceCount, 2, false).clone()
+	for i := 1; i <= boardPieceCount*2; i++ {
+		pid := PieceID(i)
+		m.SetPiece(pid, NewPieceWithClass(pid, i, 1, Scout))
+	}
+	for i := 1; i <= boardPieceCount*2; i++ {
+		pid := PieceID(i)
+		p := NewPieceWithClass(pid, i, 1, Scout)
+		if pp, ok := m.Piece(pid); p != pp || !ok {
+			t.Errorf(
+				"p=%v, ok=%v := m.Piece(%d), want p=%v, ok=%v",
+				pp, ok,
+				pid,
+				p, true,
+			)
+		}
+	}
+	if _, ok := m.Piece(NoPieceID); ok {
+		t.Errorf(
+			"_, ok=%v := m.Piece(NoPieceID), want ok=%v",
+			ok, false,
+		)
+	}
+	m.SetPiece(NoPieceID, NoPiece)
+	m.RemovePiece(NoPieceID)
+	for i, p := range m.PlayerPieces(Player1) {
+		if pid := PieceID(i + 1); pid != p.ID() {
+			t.Errorf("pid=%d, want %d", p.ID(), pid)
+		}
+	}
+	for i, p := range m.PlayerPieces(Player2) {
+		if pid := PieceID(i + boardPieceCount + 1); pid != p.ID() {
+			t.Errorf("pid=%d, want %d", p.ID(), pid)
+		}
+	}
+	for i := 1; i <= boardPieceCount*2; i += 2 {
+		m.SetPiece(PieceID(i), NoPiece)
+		m.RemovePiece(PieceID(i + 1))
+	}
+	for i := 1; i <= boardPieceCount*2; i++ {
+		if _, ok := m.Piece(PieceID(i)); ok {
+			t.Errorf(
+				"_, ok=%v := m.Piece(%d), want ok=%v",
+				ok, i, false,
+			)
+		}
+	}
+	if ps := m.Pieces(); len(ps) != 0 {
+		t.Errorf("m.Pieces() = %v, want none", ps)
+	}
+}
+
+// TestBoardCells tests that the Cells of Pieces and the PieceIDs in Cells can
+// properly be set, gotten, and removed from a board.
+func TestBoardCells(t *testing.T) {
+	t.Parallel()
+	m := newBoard(boardSize, boardSize, boardPieceCount, 2, false)
+	c := NewCell(3, 5)
+	for i := 1; i <= boardPieceCount*2; i++ {
+		if _, ok := m.Cell(PieceID(i)); ok {
+			t.Errorf(
+				"_, ok=%v := m.Cell(%d), want ok=%v",
+				ok, i, false,
+			)
+		}
+		m.SetCell(PieceID(i), c)
+	}
+	for i := 1; i <= boardPieceCount*2; i++ {
+		if cc, ok := m.Cell(PieceID(i)); cc != c || !ok {
+			t.Errorf(
+				"c=%v, ok=%v := m.Cell(%d), want c=%v, ok=%v",
+				cc, ok, i, c, true,
+			)
+		}
+	}
+	m.SetCell(NoPieceID, c)
+	if _, ok := m.Cell(NoPieceID); ok {
+		t.Errorf("_, ok=%v := m.Cell(NoPieceID), want ok=%v", ok, false)
+	}
+	for i := 0; i < boardSize; i++ {
+		for j := 0; j < boardSize; j++ {
+			m.SetPieceID(NewCell(i, j), 1)
+		}
+	}
+	for i := 0; i < boardSize; i++ {
+		for j := 0; j < boardSize; j++ {
+			pid, ok := m.PieceID(NewCell(i, j))
+			if pid != 1 || !ok {
+				t.Errorf(
+					"pid=%v, ok=%v := "+
+						"m.PieceID(NewCell(%d, %d)), "+
+						"want pid=%v, ok=%v",
+					pid, ok,
+					i, j,
+					1, true,
+				)
+			}
+			m.RemovePieceID(NewCell(i, j))
+			if _, ok := m.PieceID(NewCell(i, j)); ok {
+				t.Errorf(
+					"_, ok=%v := "+
+						"m.PieceID(NewCell(%d, %d)), "+
+						"want ok=%v",
+					ok, i, j, false,
+				)
+			}
+		}
+	}
+	m.PieceID(NoCell)
+	m.SetPieceID(NoCell, 1)
+	m.RemovePieceID(NoCell)
+}
+
+// TestBoardRectangular tests that a board with a different width and height
+// doesn't map Cells off of the grid onto Cells on the grid or onto Pieces.
+func TestBoardRectangular(t *testing.T) {
+	t.Parallel()
+	m := newBoard(3, 2, boardPieceCount, 2, false)
+	for _, c := range []Cell{
+		NewCell(0, 3), NewCell(2, 0), NewCell(-1, 2), NewCell(1, -1),
+	} {
+		m.SetPieceID(c, 1)
+		if _, ok := m.PieceID(c); ok {
+			t.Errorf(
+				"_, ok=%v := m.PieceID(%v), want ok=%v",
+				ok, c, false,
+			)
+		}
+	}
+	if ps := m.Pieces(); len(ps) != 0 {
+		t.Errorf("m.Pieces() = %v, want none", ps)
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 2; j++ {
+			if pid, ok := m.PieceID(NewCell(j, i)); ok {
+				t.Errorf(
+					"m.PieceID(%v) = %v, want %v",
+					NewCell(j, i), pid, NoPieceID,
+				)
+			}
+		}
+	}
+}
+
+// TestBoardOwners tests that only boards with owners keep the owners of Cells
+// and that owners don't overwrite the PieceIDs in Cells.
+func TestBoardOwners(t *testing.T) {
+	t.Parallel()
+	m := newBoard(3, 2, boardPieceCount, 2, true)
+	plain := newBoard(3, 2, boardPieceCount, 2, false)
+	c := NewCell(1, 2)
+	for _, b := range []board{m, plain} {
+		b.SetPieceID(c, 1)
+		b.SetOwner(c, Player2)
+		b.SetOwner(NewCell(2, 0), Player1)
+	}
+	if id := m.Owner(c); id != Player2 {
+		t.Errorf("m.Owner(%v) = %v, want %v", c, id, Player2)
+	}
+	if pid, _ := m.PieceID(c); pid != 1 {
+		t.Errorf("m.PieceID(%v) = %v, want %v", c, pid, 1)
+	}
+	if id := m.Owner(NewCell(2, 0)); id != NoPlayer {
+		t.Errorf(
+			"m.Owner(%v) = %v, want %v",
+			NewCell(2, 0), id, NoPlayer,
+		)
+	}
+	if id := plain.Owner(c); id != NoPlayer {
+		t.Errorf("plain.Owner(%v) = %v, want %v", c, id, NoPlayer)
+	}
+}
+
+// TestBoardClone tests that changing a clone of a board doesn't change the
+// original, including the Class of a Piece.
+func TestBoardClone(t *testing.T) {
+	t.Parallel()
+	m := newBoard(boardSize, boardSize, boardPieceCount, 2, false)
+	m.SetPiece(1, NewPiece(1, 1, 1))
+	m.SetCell(1, NewCell(0, 0))
+	m.SetPieceID(NewCell(0, 0), 1)
+	c := m.clone()
+	c.SetPiece(1, NewPieceWithClass(1, 2, 2, Tank))
+	c.SetCell(1, NewCell(1, 1))
+	c.RemovePieceID(NewCell(0, 0))
+	if p, _ := m.Piece(1); p != NewPiece(1, 1, 1) {
+		t.Errorf("m.Piece(1) = %v, want %v", p, NewPiece(1, 1, 1))
+	}
+	if cc, _ := m.Cell(1); cc != NewCell(0, 0) {
+		t.Errorf("m.Cell(1) = %v, want %v", cc, NewCell(0, 0))
+	}
+	if pid, _ := m.PieceID(NewCell(0, 0)); pid != 1 {
+		t.Errorf("m.PieceID(%v) = %v, want %v", NewCell(0, 0), pid, 1)
+	}
+	want := NewPieceWithClass(1, 2, 2, Tank)
+	if p, _ := c.Piece(1); p != want {
+		t.Errorf("c.Piece(1) = %v, want %v", p, want)
+	}
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MoveError describes why a Move in a Play was rejected.
+type MoveError struct {
+	// Index of the Move in the Play.
+	Index int
+	// Move which was rejected.
+	Move Move
+	// Reason the Move was rejected.
+	Reason string
+}
+
+// Error names the Move and gives the Reason.
+func (e MoveError) Error() string {
+	o := e.Move.Offset()
+	return fmt.Sprintf(
+		"move %d of piece %d by %d:%d %s",
+		e.Index, e.Move.Piece().ID(), o.Rows(), o.Columns(), e.Reason,
+	)
+}
+
+// PlayError lists every Move rejected from a Play.
+type PlayError struct {
+	// Moves rejected in the order they're in the Play.
+	Moves []MoveError
+}
+
+// Error joins the errors of every rejected Move.
+func (e *PlayError) Error() string {
+	msgs := make([]string, len(e.Moves))
+	for i, m := range e.Moves {
+		msgs[i] = m.Error()
+	}
+	return "illegal play: " + strings.Join(msgs, "; ")
+}
+
+// CheckPlay returns a PlayError listing every Move which makes the Play
+// illegal at the State as described by IsLegalPlay or nil if the Play is
+// legal.
+//
+// Each Move is checked as if the rejected Moves before it weren't in the Play.
+func CheckPlay(s *State, p Play) error {
+	if errs := checkPlay(s, p, true); len(errs) > 0 {
+		return &PlayError{Moves: errs}
+	}
+	return nil
+}
+
+// NextStateWithCheckedPlay is NextStateWithPlay for Plays which must be legal.
+//
+// The State is returned unchanged with the PlayError from CheckPlay if the Play
+// is illegal. An error is also returned if the game is over or in its
+// deployment phase.
+func NextStateWithCheckedPlay(s *State, p Play) (*State, error) {
+	if s.IsOver() {
+		return s, errors.New("game is over")
+	}
+	if s.IsDeploying() {
+		return s, errors.New("game is in its deployment phase")
+	}
+	if err := CheckPlay(s, p); err != nil {
+		return s, err
+	}
+	return NextStateWithPlay(s, p), nil
+}
+
+// NextStateWithCheckedPlays is NextStateWithPlays for Plays which must be
+// legal.
+//
+// Each Player's Play is checked with CheckPlay at their view of the State given
+// by AsPlayer. The State is returned unchanged with the error for the first
+// illegal Play in order of PlayerID. An error is also returned if a Play is
+// mapped to a Player who isn't playing the game or the game is over or in its
+// deployment phase.
+func NextStateWithCheckedPlays(
+	s *State,
+	ps map[PlayerID]Play,
+) (*State, error) {
+	if s.IsOver() {
+		return s, errors.New("game is over")
+	}
+	if s.IsDeploying() {
+		return s, errors.New("game is in its deployment phase")
+	}
+	for id := range ps {
+		if id <= NoPlayer || int(id) > s.Rules().PlayerCount() {
+			return s, &PlayerError{
+				Player: id,
+				Reason: "isn't playing the game",
+			}
+		}
+	}
+	for _, id := range s.Rules().PlayerIDs() {
+		p, ok := ps[id]
+		if !ok {
+			continue
+		}
+		if err := CheckPlay(s.AsPlayer(id), p); err != nil {
+			return s, fmt.Errorf("%v: %w", id, err)
+		}
+	}
+	return NextStateWithPlays(s, ps), nil
+}
@@ -0,0 +1,245 @@
+package game_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// checkState where player 1 has game.Pieces 1 and 2 in the first 2
+// game.Cells of the top row of a 5 by 5 board with a blocked game.Cell below
+// game.Piece 1 and player 2 has game.Piece 3 diagonally below game.Piece 2
+// and game.Piece 4 in the bottom-right corner.
+func checkState() *game.State {
+	r := game.NewRules(time.Second, 2, 2, 1, 1, 1).
+		WithBoardSize(5, 5).
+		WithTerrain(game.NewTerrain(game.NewCell(1, 0)))
+	return game.NewStateFromInfo(
+		r,
+		game.Player1,
+		nil, nil,
+		map[game.Cell]game.Piece{
+			game.NewCell(0, 0): game.NewPiece(1, 2, 1),
+			game.NewCell(0, 1): game.NewPiece(2, 2, 1),
+			game.NewCell(1, 2): game.NewPiece(3, 2, 1),
+			game.NewCell(4, 4): game.NewPiece(4, 2, 1),
+		},
+	)
+}
+
+// TestCheckPlay tests that game.CheckPlay rejects every illegal game.Move in a
+// game.Play with the reason.
+func TestCheckPlay(t *testing.T) {
+	t.Parallel()
+	s := checkState()
+	p1, p2 := game.NewPiece(1, 2, 1), game.NewPiece(2, 2, 1)
+	cases := []struct {
+		name     string
+		play     game.Play
+		rejected map[int]string
+	}{
+		{
+			name: "legal",
+			play: game.Play{
+				game.NewMove(p1, game.SouthEast),
+				game.NewMove(p2, game.SouthEast),
+			},
+		},
+		{
+			name: "not your piece",
+			play: game.Play{
+				game.NewMove(
+					game.NewPiece(3, 2, 1),
+					game.North,
+				),
+			},
+			rejected: map[int]string{0: "another player's piece"},
+		},
+		{
+			name:     "off board",
+			play:     game.Play{game.NewMove(p1, game.North)},
+			rejected: map[int]string{0: "off the board"},
+		},
+		{
+			name:     "blocked",
+			play:     game.Play{game.NewMove(p1, game.South)},
+			rejected: map[int]string{0: "blocked cell"},
+		},
+		{
+			name:     "blocked by ally",
+			play:     game.Play{game.NewMove(p1, game.East)},
+			rejected: map[int]string{0: "ally piece 2"},
+		},
+		{
+			name: "duplicate piece",
+			play: game.Play{
+				game.NewMove(p2, game.South),
+				game.NewMove(p2, game.South),
+			},
+			rejected: map[int]string{1: "more times"},
+		},
+		{
+			name: "same cell",
+			play: game.Play{
+				game.NewMove(p1, game.SouthEast),
+				game.NewMove(p2, game.South),
+			},
+			rejected: map[int]string{1: "piece 1 already moved"},
+		},
+		{
+			name: "fabricated stats",
+			play: game.Play{
+				game.NewMove(
+					game.NewPiece(2, 2, 9),
+					game.SouthEast,
+				),
+			},
+			rejected: map[int]string{0: "2|9 instead of its 2|1"},
+		},
+		{
+			name: "several",
+			play: game.Play{
+				game.NewMove(p1, game.North),
+				game.NewMove(p2, game.South),
+				game.NewMove(p1, game.East),
+			},
+			rejected: map[int]string{
+				0: "off the board",
+				2: "ally piece 2",
+			},
+		},
+	}
+	for _, c := range cases {
+		err := game.CheckPlay(s, c.play)
+		if legal := game.IsLegalPlay(s, c.play); legal != (err == nil) {
+			t.Errorf(
+				"%s: game.IsLegalPlay(s, p) = %v, "+
+					"but game.CheckPlay(s, p) = %v",
+				c.name, legal, err,
+			)
+		}
+		if len(c.rejected) == 0 {
+			if err != nil {
+				t.Errorf(
+					"%s: game.CheckPlay(s, p) = %v, "+
+						"want %v",
+					c.name, err, nil,
+				)
+			}
+			continue
+		}
+		var pe *game.PlayError
+		if !errors.As(err, &pe) || len(pe.Moves) != len(c.rejected) {
+			t.Errorf(
+				"%s: game.CheckPlay(s, p) = %v, "+
+					"want %d rejected",
+				c.name, err, len(c.rejected),
+			)
+			continue
+		}
+		for _, m := range pe.Moves {
+			want, ok := c.rejected[m.Index]
+			if !ok || !strings.Contains(m.Reason, want) ||
+				m.Move != c.play[m.Index] {
+				t.Errorf(
+					"%s: move %d reason = %q, want %q",
+					c.name, m.Index, m.Reason, want,
+				)
+			}
+		}
+	}
+}
+
+// TestNextStateWithCheckedPlay tests that game.NextStateWithCheckedPlay only
+// makes legal game.Plays and that game.NextStateWithPlay ignores the stats
+// and owners game.Moves claim.
+func TestNextStateWithCheckedPlay(t *testing.T) {
+	t.Parallel()
+	s := checkState()
+	fake := game.NewPiece(2, 2, 9)
+	n, err := game.NextStateWithCheckedPlay(s, game.Play{
+		game.NewMove(fake, game.SouthEast),
+	})
+	if n != s || err == nil {
+		t.Errorf(
+			"game.NextStateWithCheckedPlay(s, p) = %v, %v, "+
+				"want s, an error",
+			n, err,
+		)
+	}
+	n = game.NextStateWithPlay(s, game.Play{
+		game.NewMove(fake, game.SouthEast),
+		game.NewMove(game.NewPiece(4, 2, 1), game.North),
+	})
+	if p := n.PieceForCell(game.NewCell(1, 2)); p.Life() != 1 {
+		t.Errorf("attacked piece life = %d, want %d", p.Life(), 1)
+	}
+	if p := n.PieceForCell(game.NewCell(4, 4)); p.ID() != 4 {
+		t.Errorf("enemy piece moved from %v", game.NewCell(4, 4))
+	}
+	n, err = game.NextStateWithCheckedPlay(s, game.Play{
+		game.NewMove(game.NewPiece(2, 2, 1), game.SouthEast),
+	})
+	if err != nil || n.CurrentPlayer() != game.Player2 {
+		t.Errorf(
+			"game.NextStateWithCheckedPlay(s, p) = %v, %v, "+
+				"want the next state, %v",
+			n, err, nil,
+		)
+	}
+}
+
+// TestNextStateWithCheckedPlays tests that game.NextStateWithCheckedPlays
+// checks every game.Player's game.Play from their own view of the game.State
+// and only makes the game.Plays if they're all legal.
+func TestNextStateWithCheckedPlays(t *testing.T) {
+	t.Parallel()
+	r := game.StandardRules.WithPlayMode(game.SimultaneousPlays)
+	s := game.NewState(r, nil, nil)
+	p1, p2 := s.Player1Pieces()[0], s.Player2Pieces()[0]
+	legal := map[game.PlayerID]game.Play{
+		game.Player1: {game.NewMove(p1, game.South)},
+		game.Player2: {game.NewMove(p2, game.North)},
+	}
+	n, err := game.NextStateWithCheckedPlays(s, legal)
+	if err != nil || n.CellForPiece(p2) == s.CellForPiece(p2) {
+		t.Errorf(
+			"game.NextStateWithCheckedPlays(s, legal) = %v, %v, "+
+				"want the next state, %v",
+			n, err, nil,
+		)
+	}
+	var pe *game.PlayError
+	jump := game.NewOffsetMove(p2, game.NewOffset(-5, 0))
+	for _, ps := range []map[game.PlayerID]game.Play{
+		{
+			game.Player1: {game.NewMove(p1, game.South)},
+			game.Player2: {jump},
+		},
+		{game.Player2: {game.NewMove(p1, game.South)}},
+	} {
+		n, err := game.NextStateWithCheckedPlays(s, ps)
+		if n != s || !errors.As(err, &pe) {
+			t.Errorf(
+				"game.NextStateWithCheckedPlays(s, %v) = "+
+					"%v, %v, want s, a game.PlayError",
+				ps, n, err,
+			)
+		}
+	}
+	var ple *game.PlayerError
+	_, err = game.NextStateWithCheckedPlays(
+		s,
+		map[game.PlayerID]game.Play{game.Player3: nil},
+	)
+	if !errors.As(err, &ple) || ple.Player != game.Player3 {
+		t.Errorf(
+			"game.NextStateWithCheckedPlays(s, ps) error = %v, "+
+				"want a game.PlayerError for %v",
+			err, game.Player3,
+		)
+	}
+}
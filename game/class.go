@@ -0,0 +1,196 @@
+package game
+
+// Class of a Piece which determines the life and damage it starts with, how
+// much these increase when it levels up, and how many Moves it can make in a
+// Play.
+//
+// The zero-value Class is StandardClass whose stats are the ones given to the
+// Rules. Every other Class is created with NewClass.
+type Class struct {
+	name                         string
+	life, damage                 int
+	lifeIncrease, damageIncrease int
+	moves                        int
+}
+
+// NewClass with the name, starting life and damage, life and damage increases,
+// and number of Moves its Pieces can make in a Play.
+//
+// Pieces can always make at least 1 Move in a Play.
+func NewClass(name string, l, d, li, di, moves int) Class {
+	if moves < 1 {
+		moves = 1
+	}
+	return Class{
+		name:           name,
+		life:           l,
+		damage:         d,
+		lifeIncrease:   li,
+		damageIncrease: di,
+		moves:          moves,
+	}
+}
+
+// StandardClass is the Class of Pieces when the Rules don't give them another
+// one.
+//
+// Its stats come from the Rules and its Pieces can make 1 Move in a Play.
+//
+// Note that this is the same as the zero-value for Class.
+var StandardClass = Class{}
+
+// Classes which are commonly used in rosters.
+var (
+	// Scout is fragile and weak but can make 2 Moves in a Play.
+	Scout = NewClass("scout", 2, 1, 1, 0, 2)
+	// Tank is hard to destroy and gains more life when it levels up.
+	Tank = NewClass("tank", 5, 1, 2, 0, 1)
+	// Striker is fragile but hits hard and gains more damage when it levels
+	// up.
+	Striker = NewClass("striker", 2, 2, 0, 2, 1)
+)
+
+// Classes returns StandardClass and all the commonly used Classes.
+func Classes() []Class {
+	return []Class{StandardClass, Scout, Tank, Striker}
+}
+
+// Name of the Class.
+//
+// StandardClass is named "standard".
+func (c Class) Name() string {
+	if c == StandardClass {
+		return "standard"
+	}
+	return c.name
+}
+
+// Life Pieces of the Class start with.
+func (c Class) Life() int {
+	return c.life
+}
+
+// Damage Pieces of the Class start with.
+func (c Class) Damage() int {
+	return c.damage
+}
+
+// LifeIncrease Pieces of the Class gain when they level up.
+func (c Class) LifeIncrease() int {
+	return c.lifeIncrease
+}
+
+// DamageIncrease Pieces of the Class gain when they level up.
+func (c Class) DamageIncrease() int {
+	return c.damageIncrease
+}
+
+// Moves Pieces of the Class can make in a Play.
+func (c Class) Moves() int {
+	if c.moves < 1 {
+		return 1
+	}
+	return c.moves
+}
+
+// rosterTable of Classes for each Player indexed by PlayerID minus 1.
+type rosterTable [MaxPlayerCount][]Class
+
+// WithRoster returns a copy of the Rules where the Player with the PlayerID's
+// Pieces have the Classes in order of PieceID.
+//
+// Pieces without a Class in the roster have StandardClass. Rosters which would
+// give different Classes the same name in the Rules, including a Class other
+// than StandardClass named "standard", are ignored since Class looks Classes up
+// by name.
+func (r Rules) WithRoster(id PlayerID, classes ...Class) Rules {
+	if id <= NoPlayer || int(id) > MaxPlayerCount {
+		return r
+	}
+	rs := &rosterTable{}
+	if r.rosters != nil {
+		*rs = *r.rosters
+	}
+	rs[id-1] = append([]Class{}, classes...)
+	if !uniqueNames(rs) {
+		return r
+	}
+	r.rosters = rs
+	return r
+}
+
+// uniqueNames returns true iff no different Classes in the rosterTable share a
+// name.
+func uniqueNames(rs *rosterTable) bool {
+	named := map[string]Class{StandardClass.Name(): StandardClass}
+	for _, classes := range rs {
+		for _, c := range classes {
+			if other, ok := named[c.Name()]; ok && other != c {
+				return false
+			}
+			named[c.Name()] = c
+		}
+	}
+	return true
+}
+
+// Roster of Classes for the Player with the PlayerID's Pieces in order of
+// PieceID.
+func (r Rules) Roster(id PlayerID) []Class {
+	if r.rosters == nil || id <= NoPlayer || int(id) > MaxPlayerCount {
+		return nil
+	}
+	return append([]Class{}, r.rosters[id-1]...)
+}
+
+// Class with the name in any Player's roster.
+//
+// StandardClass is returned for "standard" and false is returned if no Class
+// has the name.
+func (r Rules) Class(name string) (Class, bool) {
+	if name == StandardClass.Name() {
+		return StandardClass, true
+	}
+	if r.rosters == nil {
+		return StandardClass, false
+	}
+	for _, classes := range r.rosters {
+		for _, c := range classes {
+			if c.Name() == name {
+				return c, true
+			}
+		}
+	}
+	return StandardClass, false
+}
+
+// pieceClass returns the Class of the Piece which is the ith Piece of the
+// Player with the PlayerID.
+func (r Rules) pieceClass(id PlayerID, i int) Class {
+	if r.rosters == nil || id <= NoPlayer || int(id) > MaxPlayerCount {
+		return StandardClass
+	}
+	if roster := r.rosters[id-1]; i < len(roster) {
+		return roster[i]
+	}
+	return StandardClass
+}
+
+// newPiece with the PieceID and Class starting with the Class' life and
+// damage.
+func (r Rules) newPiece(pid PieceID, c Class) Piece {
+	if c == StandardClass {
+		return NewPiece(pid, r.Life(), r.Damage())
+	}
+	return NewPieceWithClass(pid, c.Life(), c.Damage(), c)
+}
+
+// LevelUp returns the Piece after increasing its life and damage by its
+// Class' increases.
+func (r Rules) LevelUp(p Piece) Piece {
+	li, di := r.LifeIncrease(), r.DamageIncrease()
+	if c := p.Class(); c != StandardClass {
+		li, di = c.LifeIncrease(), c.DamageIncrease()
+	}
+	return p.withStats(p.Life()+li, p.Damage()+di)
+}
@@ -0,0 +1,181 @@
+package game_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// TestRulesWithRoster tests that game.NewState gives each game.Piece the
+// game.Class in its game.Player's roster and the game.Rules' stats otherwise
+// and that rosters giving different game.Classes the same name are ignored.
+func TestRulesWithRoster(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(time.Second, 3, 3, 1, 1, 1).
+		WithRoster(game.Player1, game.Scout, game.Tank)
+	s := game.NewState(r, nil, nil)
+	cases := []struct {
+		piece        game.PieceID
+		class        game.Class
+		life, damage int
+	}{
+		{piece: 1, class: game.Scout, life: 2, damage: 1},
+		{piece: 2, class: game.Tank, life: 5, damage: 1},
+		{piece: 3, class: game.StandardClass, life: 3, damage: 1},
+		{piece: 4, class: game.StandardClass, life: 3, damage: 1},
+	}
+	pieces := make(map[game.PieceID]game.Piece)
+	for _, p := range s.Pieces() {
+		pieces[p.ID()] = p
+	}
+	for _, c := range cases {
+		p := pieces[c.piece]
+		if p.Class() != c.class || p.Life() != c.life ||
+			p.Damage() != c.damage {
+			t.Errorf(
+				"piece %d = %s %d|%d, want %s %d|%d",
+				c.piece, p.Class().Name(), p.Life(), p.Damage(),
+				c.class.Name(), c.life, c.damage,
+			)
+		}
+	}
+	if c, ok := r.Class("tank"); !ok || c != game.Tank {
+		t.Errorf("r.Class(%q) = %v, %v, want %v, %v",
+			"tank", c.Name(), ok, game.Tank.Name(), true)
+	}
+	if _, ok := r.Class("striker"); ok {
+		t.Errorf("r.Class(%q) ok = %v, want %v", "striker", ok, false)
+	}
+	p2 := game.Player2
+	for _, bad := range []game.Class{
+		game.NewClass("tank", 1, 9, 1, 1, 1),
+		game.NewClass("standard", 9, 9, 1, 1, 1),
+	} {
+		if got := r.WithRoster(p2, bad).Roster(p2); len(got) != 0 {
+			t.Errorf("r.Roster(%v) = %v, want none", p2, got)
+		}
+	}
+	same := r.WithRoster(p2, game.Tank, game.Tank)
+	if got := same.Roster(p2); len(got) != 2 {
+		t.Errorf("same.Roster(%v) = %v, want 2 tanks", p2, got)
+	}
+}
+
+// scoutState where player 1 has a game.Scout in the top-left corner and
+// player 2 has a standard game.Piece with 2 life in the bottom-right corner of
+// a 3 by 3 board.
+func scoutState() (*game.State, game.Piece) {
+	r := game.NewRules(time.Second, 1, 2, 1, 1, 1).
+		WithRoster(game.Player1, game.Scout)
+	scout := game.NewPieceWithClass(1, 2, 1, game.Scout)
+	s := game.NewStateFromInfo(
+		r,
+		game.Player1,
+		nil, nil,
+		map[game.Cell]game.Piece{
+			game.NewCell(0, 0): scout,
+			game.NewCell(2, 2): game.NewPiece(2, 2, 1),
+		},
+	)
+	return s, scout
+}
+
+// TestClassMoves tests that game.Pieces can make as many game.Moves in a
+// game.Play as their game.Class allows.
+func TestClassMoves(t *testing.T) {
+	t.Parallel()
+	s, scout := scoutState()
+	cases := []struct {
+		name  string
+		play  game.Play
+		legal bool
+	}{
+		{
+			name:  "one",
+			play:  game.Play{game.NewMove(scout, game.East)},
+			legal: true,
+		},
+		{
+			name: "two",
+			play: game.Play{
+				game.NewMove(scout, game.East),
+				game.NewMove(scout, game.East),
+			},
+			legal: true,
+		},
+		{
+			name: "back",
+			play: game.Play{
+				game.NewMove(scout, game.East),
+				game.NewMove(scout, game.West),
+			},
+			legal: true,
+		},
+		{
+			name: "off board",
+			play: game.Play{
+				game.NewMove(scout, game.East),
+				game.NewMove(scout, game.North),
+			},
+		},
+		{
+			name: "three",
+			play: game.Play{
+				game.NewMove(scout, game.East),
+				game.NewMove(scout, game.South),
+				game.NewMove(scout, game.West),
+			},
+		},
+	}
+	for _, c := range cases {
+		if legal := game.IsLegalPlay(s, c.play); legal != c.legal {
+			t.Errorf(
+				"%s: game.IsLegalPlay(s, %v) = %v, want %v",
+				c.name, c.play, legal, c.legal,
+			)
+		}
+	}
+	ps := game.LegalPlays(s)
+	if len(ps) != 22 {
+		t.Errorf("len(game.LegalPlays(s)) = %d, want %d", len(ps), 22)
+	}
+	n := game.NextStateWithPlay(s, game.Play{
+		game.NewMove(scout, game.East),
+		game.NewMove(scout, game.South),
+	})
+	if p := n.PieceForCell(game.NewCell(1, 1)); p.ID() != scout.ID() {
+		t.Errorf(
+			"n.PieceForCell(%v) = %v, want %v",
+			game.NewCell(1, 1), p, scout,
+		)
+	}
+}
+
+// TestClassLevelUp tests that a game.Piece which attacks twice in a game.Play
+// deals damage twice and levels up by its game.Class' increases.
+func TestClassLevelUp(t *testing.T) {
+	t.Parallel()
+	s, scout := scoutState()
+	s = game.NextStateWithPlay(s, game.Play{
+		game.NewMove(scout, game.SouthEast),
+	})
+	s = game.NextStateWithPlay(s, game.Play{})
+	scout = s.PieceForCell(game.NewCell(1, 1))
+	s = game.NextStateWithPlay(s, game.Play{
+		game.NewMove(scout, game.SouthEast),
+		game.NewMove(scout, game.SouthEast),
+	})
+	if s.Winner() != game.Player1 {
+		t.Errorf("s.Winner() = %v, want %v", s.Winner(), game.Player1)
+	}
+	p := s.PieceForCell(game.NewCell(1, 1))
+	if p.Life() != 3 || p.Damage() != 1 || p.Class() != game.Scout {
+		t.Errorf(
+			"s.PieceForCell(%v) = %s %d|%d, want %s %d|%d",
+			game.NewCell(1, 1),
+			p.Class().Name(), p.Life(), p.Damage(),
+			game.Scout.Name(), 3, 1,
+		)
+	}
+}
@@ -0,0 +1,181 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Deployer is a Player who chooses where to place their Pieces during a
+// deployment phase.
+//
+// Players who aren't Deployers leave their Pieces where they start if those
+// Cells are in their home zone and otherwise fill their home zone in order.
+type Deployer interface {
+	Player
+	// Deploy returns the Cells to place the current Player's Pieces in
+	// order of PieceID.
+	Deploy(*State) []Cell
+}
+
+// IsDeploying returns true iff the game is in its deployment phase at the
+// State.
+//
+// The current Player is the Player who is deploying.
+func (s *State) IsDeploying() bool {
+	return s.deploying
+}
+
+// WithDeploying returns a copy of the State which is in its deployment phase
+// iff deploying is true and the Rules have HomeZones.
+//
+// This is meant for restoring games already in progress, such as ones created
+// with NewStateFromInfo.
+func (s *State) WithDeploying(deploying bool) *State {
+	s = clone(s)
+	s.deploying = deploying && s.Rules().HomeZones() != nil
+	return s
+}
+
+// Deploy the current Player's Pieces in order of PieceID to the Cells during
+// the deployment phase.
+//
+// The next State is returned where the next Player deploys. The deployment
+// phase ends after the last Player deploys and Player one makes the first
+// Play.
+//
+// An error is returned with the State unchanged if the game isn't in its
+// deployment phase or the Cells aren't a valid deployment. Cells are valid if
+// there is one for each of the Player's Pieces and each is distinct, open,
+// not held by another Player's Piece, and in the Player's home zone.
+func Deploy(s *State, cells []Cell) (*State, error) {
+	if !s.IsDeploying() {
+		return s, errors.New("game isn't in its deployment phase")
+	}
+	if err := checkDeployment(s, s.CurrentPlayer(), cells); err != nil {
+		return s, err
+	}
+	return deploy(s, cells), nil
+}
+
+// checkDeployment returns an error if the Cells aren't a valid deployment for
+// the Player with the PlayerID as described in Deploy.
+func checkDeployment(s *State, id PlayerID, cells []Cell) error {
+	if n := len(s.PlayerPieces(id)); len(cells) != n {
+		return fmt.Errorf("%d cells given for %d pieces", len(cells), n)
+	}
+	seen := make(map[Cell]bool, len(cells))
+	for _, c := range cells {
+		if err := checkDeploymentCell(s, id, c); err != nil {
+			return err
+		}
+		if seen[c] {
+			return fmt.Errorf("cell %v is used more than once", c)
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+// checkDeploymentCell returns an error if the Player with the PlayerID can't
+// deploy a Piece to the Cell.
+func checkDeploymentCell(s *State, id PlayerID, c Cell) error {
+	switch {
+	case !s.Rules().IsOnBoard(c):
+		return fmt.Errorf("cell %v is off the board", c)
+	case s.Rules().IsBlocked(c):
+		return fmt.Errorf("cell %v is blocked", c)
+	case !s.Rules().HomeZones().Contains(id, c):
+		return fmt.Errorf("cell %v is outside the home zone", c)
+	}
+	if p := s.PieceForCell(c); p != NoPiece && s.PlayerForPiece(p) != id {
+		return fmt.Errorf("cell %v holds another player's piece", c)
+	}
+	return nil
+}
+
+// deploy the current Player's Pieces to the Cells without checking them and
+// move on to the next Player.
+func deploy(s *State, cells []Cell) *State {
+	previous := s
+	s = clone(s)
+	if s.history {
+		s.previous = previous
+		s.lastPlay = nil
+	}
+	s.events = nil
+	var pids []PieceID
+	for _, p := range s.CurrentPlayerPieces() {
+		pids = append(pids, p.ID())
+	}
+	if len(pids) == len(cells) {
+		s.relocate(pids, cells)
+	}
+	next := s.NextPlayer()
+	if next <= s.CurrentPlayer() {
+		s.deploying = false
+		// Nothing before the first Play can repeat.
+		s.positions = nil
+	}
+	s.setCurrentPlayer(next)
+	s.recordPosition()
+	return s
+}
+
+// defaultDeployment returns the Cells the Player with the PlayerID deploys to
+// if they don't choose as described for Deployer.
+//
+// The Cells the Pieces are already in are returned if the home zone is too
+// small to hold the Player's Pieces.
+func defaultDeployment(s *State, id PlayerID) []Cell {
+	var current []Cell
+	for _, p := range s.PlayerPieces(id) {
+		current = append(current, s.CellForPiece(p))
+	}
+	if checkDeployment(s, id, current) == nil {
+		return current
+	}
+	var cells []Cell
+	for _, c := range s.Rules().HomeZones().Cells(id) {
+		if len(cells) == len(current) {
+			break
+		}
+		if checkDeploymentCell(s, id, c) == nil {
+			cells = append(cells, c)
+		}
+	}
+	if checkDeployment(s, id, cells) != nil {
+		return current
+	}
+	return cells
+}
+
+// nextDeployment returns the next State where the current Player deploys
+// their Pieces.
+//
+// Deployers have the Rules' TimerDuration to choose. Players who take longer or
+// choose an invalid deployment deploy as if they weren't Deployers. The State
+// is returned unchanged along with the Context's error if the Context is done
+// first.
+func nextDeployment(ctx context.Context, s *State) (*State, error) {
+	id := s.CurrentPlayer()
+	cells := defaultDeployment(s, id)
+	if d, ok := s.Player(id).(Deployer); ok {
+		turn := ctx
+		if td := s.Rules().TimerDuration(); td > 0 {
+			var cancel context.CancelFunc
+			turn, cancel = context.WithTimeout(ctx, td)
+			defer cancel()
+		}
+		chosen, err := orCancel(turn, func() ([]Cell, error) {
+			return d.Deploy(s.PlayerView(id)), nil
+		})
+		if err := ctx.Err(); err != nil {
+			return s, err
+		}
+		if err == nil && checkDeployment(s, id, chosen) == nil {
+			cells = chosen
+		}
+	}
+	return deploy(s, cells), nil
+}
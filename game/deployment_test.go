@@ -0,0 +1,195 @@
+package game_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// deploymentRules are game.Rules with home zones for a deployment phase where
+// player 2's starting game.Cell (3, 0) is in player 1's home zone.
+func deploymentRules(t *testing.T) game.Rules {
+	at := game.NewCell
+	zones, err := game.ParseLayout([]string{
+		"11.",
+		"1..",
+		"..2",
+		"122",
+	})
+	if err != nil {
+		t.Fatalf("game.ParseLayout(rows) error = %v, want %v", err, nil)
+	}
+	return game.NewRules(time.Second, 2, 1, 1, 1, 1).
+		WithBoardSize(3, 4).
+		WithTerrain(game.NewTerrain(at(1, 1))).
+		WithHomeZones(zones)
+}
+
+// TestDeploy tests that game.Deploy places the current game.Player's
+// game.Pieces and rejects invalid deployments.
+func TestDeploy(t *testing.T) {
+	t.Parallel()
+	at := game.NewCell
+	s := game.NewState(deploymentRules(t), nil, nil)
+	if !s.IsDeploying() {
+		t.Fatalf("s.IsDeploying() = %v, want %v", false, true)
+	}
+	cases := []struct {
+		name  string
+		cells []game.Cell
+		ok    bool
+	}{
+		{
+			name:  "valid",
+			cells: []game.Cell{at(1, 0), at(0, 0)},
+			ok:    true,
+		},
+		{
+			name:  "too few",
+			cells: []game.Cell{at(0, 0)},
+		},
+		{
+			name:  "off board",
+			cells: []game.Cell{at(0, 0), at(-1, 0)},
+		},
+		{
+			name:  "blocked",
+			cells: []game.Cell{at(0, 0), at(1, 1)},
+		},
+		{
+			name:  "outside home zone",
+			cells: []game.Cell{at(0, 0), at(0, 2)},
+		},
+		{
+			name:  "repeated",
+			cells: []game.Cell{at(0, 0), at(0, 0)},
+		},
+		{
+			name:  "enemy held",
+			cells: []game.Cell{at(0, 0), at(3, 0)},
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			n, err := game.Deploy(s, c.cells)
+			if (err == nil) != c.ok {
+				t.Fatalf(
+					"game.Deploy(s, %v) error = %v, "+
+						"want ok %v",
+					c.cells, err, c.ok,
+				)
+			}
+			if !c.ok {
+				if n != s {
+					t.Errorf("game.Deploy(s, %v) changed s",
+						c.cells)
+				}
+				return
+			}
+			for i, cell := range c.cells {
+				p := n.PieceForCell(cell)
+				if p == game.NoPiece || int(p.ID()) != i+1 {
+					t.Errorf(
+						"n.PieceForCell(%v) = %v, "+
+							"want piece %d",
+						cell, p, i+1,
+					)
+				}
+			}
+			if n.CurrentPlayer() != game.Player2 {
+				t.Errorf(
+					"n.CurrentPlayer() = %v, want %v",
+					n.CurrentPlayer(), game.Player2,
+				)
+			}
+		})
+	}
+	plain := game.NewState(game.StandardRules, nil, nil)
+	if _, err := game.Deploy(plain, nil); err == nil {
+		t.Errorf("game.Deploy(plain, nil) error = %v, want error", err)
+	}
+}
+
+// deployer game.Deployer which always chooses the same game.Cells.
+type deployer struct {
+	cells []game.Cell
+}
+
+// Play for deployer.
+func (p deployer) Play(s *game.State) game.Play {
+	return nil
+}
+
+// Deploy for deployer.
+func (p deployer) Deploy(s *game.State) []game.Cell {
+	return p.cells
+}
+
+// TestDeploymentPhase tests that game.NextState lets each game.Player deploy
+// in turn, falls back to filling the home zone in order for invalid choices
+// and game.Players who aren't game.Deployers, and then starts the game.
+func TestDeploymentPhase(t *testing.T) {
+	t.Parallel()
+	at := game.NewCell
+	cases := []struct {
+		name   string
+		player game.Player
+		want   []game.Cell
+	}{
+		{
+			name: "chosen",
+			player: deployer{cells: []game.Cell{
+				at(1, 0),
+				at(0, 1),
+			}},
+			want: []game.Cell{at(1, 0), at(0, 1)},
+		},
+		{
+			name:   "invalid",
+			player: deployer{},
+			want:   []game.Cell{at(0, 0), at(0, 1)},
+		},
+		{
+			name:   "not a deployer",
+			player: normal1{},
+			want:   []game.Cell{at(0, 0), at(0, 1)},
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			r := deploymentRules(t)
+			s := game.NewState(r, c.player, normal1{})
+			s = game.NextState(game.NextState(s))
+			if s.IsDeploying() {
+				t.Errorf("s.IsDeploying() = %v, want %v",
+					true, false)
+			}
+			cp := s.CurrentPlayer()
+			if cp != game.Player1 || s.Turn() != 0 {
+				t.Errorf(
+					"s.CurrentPlayer(), s.Turn() = "+
+						"%v, %d, want %v, %d",
+					cp, s.Turn(),
+					game.Player1, 0,
+				)
+			}
+			want := append(c.want, at(2, 2),
+				at(3, 1))
+			for i, cell := range want {
+				p := s.PieceForCell(cell)
+				if p == game.NoPiece || int(p.ID()) != i+1 {
+					t.Errorf(
+						"s.PieceForCell(%v) = %v, "+
+							"want piece %d",
+						cell, p, i+1,
+					)
+				}
+			}
+		})
+	}
+}
@@ -30,6 +30,17 @@ func Directions() []Direction {
 	}
 }
 
+// Offset of moving 1 Cell in the Direction.
+//
+// NoDirection has the zero-value Offset.
+func (d Direction) Offset() Offset {
+	if d < NoDirection || int(d) >= len(nextCells) {
+		return Offset{}
+	}
+	c := nextCells[d]
+	return NewOffset(c.Row(), c.Column())
+}
+
 // String representation of the Direction.
 func (d Direction) String() string {
 	switch d {
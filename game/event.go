@@ -0,0 +1,101 @@
+package game
+
+// EventKind is what happened in an Event.
+type EventKind int
+
+// EventKinds which can happen while a Play is made.
+const (
+	// MovedEvent is a Piece moving From one Cell To another.
+	MovedEvent EventKind = iota // EventKind zero-value.
+	// BlockedEvent is a Piece failing to move From its Cell To another
+	// and staying where it is.
+	BlockedEvent
+	// AttackedEvent is a Piece From a Cell attacking the Target in the To
+	// Cell and dealing Damage which leaves the Target with Life.
+	AttackedEvent
+	// DestroyedEvent is a Piece being removed From its Cell.
+	DestroyedEvent
+	// LeveledUpEvent is a Piece in the From Cell leveling up to Life and
+	// Damage.
+	LeveledUpEvent
+)
+
+// String representation of the EventKind.
+func (k EventKind) String() string {
+	switch k {
+	case MovedEvent:
+		return "moved"
+	case BlockedEvent:
+		return "blocked"
+	case AttackedEvent:
+		return "attacked"
+	case DestroyedEvent:
+		return "destroyed"
+	case LeveledUpEvent:
+		return "leveled up"
+	default:
+		return ""
+	}
+}
+
+// Event which happened while a Play was made.
+//
+// The fields used depend on the Kind as described for each EventKind. To is
+// the same as From for Events which don't involve another Cell.
+type Event struct {
+	Kind   EventKind
+	Piece  PieceID
+	Target PieceID
+	From   Cell
+	To     Cell
+	Life   int
+	Damage int
+}
+
+// Events which happened in order while the Plays that reached the State were
+// made.
+//
+// Pieces move, get blocked, and attack in the order of the Moves. Attacked
+// Pieces which are destroyed follow along with the Pieces which level up from
+// destroying them. States which weren't reached by a Play have no Events.
+func (s *State) Events() []Event {
+	return append([]Event(nil), s.events...)
+}
+
+// emit the Event in the State.
+func (s *State) emit(e Event) {
+	s.events = append(s.events, e)
+}
+
+// emitDestroyed emits the DestroyedEvent of the Piece.
+func (s *State) emitDestroyed(p Piece) {
+	c := s.CellForPiece(p)
+	s.emit(Event{Kind: DestroyedEvent, Piece: p.ID(), From: c, To: c})
+}
+
+// levelUp the Piece in the State and emit its LeveledUpEvent.
+func (s *State) levelUp(p Piece) {
+	p = s.Rules().LevelUp(p)
+	s.setPiece(p.ID(), p)
+	c := s.CellForPiece(p)
+	s.emit(Event{
+		Kind:   LeveledUpEvent,
+		Piece:  p.ID(),
+		From:   c,
+		To:     c,
+		Life:   p.Life(),
+		Damage: p.Damage(),
+	})
+}
+
+// visibleEvents returns the Events which happened in Cells visible in the
+// State.
+func (s *State) visibleEvents(es []Event) []Event {
+	var visible []Event
+	for _, e := range es {
+		if s.IsVisible(e.From) || s.IsVisible(e.To) {
+			visible = append(visible, e)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,163 @@
+package game_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// eventState where player 1 has game.Pieces 1, 2, and 3 along the top row of
+// a 3 by 3 board and player 2 has game.Piece 4 in the center, game.Piece 5 in
+// the bottom-right corner, and game.Piece 6 in the bottom-left corner.
+//
+// Every game.Piece has 1 life and does 1 damage.
+func eventState(pm game.PlayMode) *game.State {
+	r := game.NewRules(time.Second, 3, 1, 1, 1, 1).WithPlayMode(pm)
+	return game.NewStateFromInfo(
+		r,
+		game.Player1,
+		nil, nil,
+		map[game.Cell]game.Piece{
+			game.NewCell(0, 0): game.NewPiece(1, 1, 1),
+			game.NewCell(0, 1): game.NewPiece(2, 1, 1),
+			game.NewCell(0, 2): game.NewPiece(3, 1, 1),
+			game.NewCell(1, 1): game.NewPiece(4, 1, 1),
+			game.NewCell(2, 2): game.NewPiece(5, 1, 1),
+			game.NewCell(2, 0): game.NewPiece(6, 1, 1),
+		},
+	)
+}
+
+// TestEvents tests that game.NextStateWithPlay and game.NextStateWithPlays
+// emit the game.Events of resolving game.Plays in order.
+func TestEvents(t *testing.T) {
+	t.Parallel()
+	at := game.NewCell
+	piece := func(id game.PieceID) game.Piece {
+		return game.NewPiece(id, 1, 1)
+	}
+	cases := []struct {
+		name   string
+		mode   game.PlayMode
+		plays  map[game.PlayerID]game.Play
+		events []game.Event
+	}{
+		{
+			name: "alternating",
+			mode: game.AlternatingPlays,
+			plays: map[game.PlayerID]game.Play{
+				game.Player1: {
+					game.NewMove(piece(1), game.South),
+					game.NewMove(piece(3), game.West),
+					game.NewMove(piece(2), game.South),
+				},
+			},
+			events: []game.Event{
+				{
+					Kind:  game.MovedEvent,
+					Piece: 1,
+					From:  at(0, 0),
+					To:    at(1, 0),
+				},
+				{
+					Kind:  game.BlockedEvent,
+					Piece: 3,
+					From:  at(0, 2),
+					To:    at(0, 1),
+				},
+				{
+					Kind:   game.AttackedEvent,
+					Piece:  2,
+					Target: 4,
+					From:   at(0, 1),
+					To:     at(1, 1),
+					Life:   0,
+					Damage: 1,
+				},
+				{
+					Kind:  game.DestroyedEvent,
+					Piece: 4,
+					From:  at(1, 1),
+					To:    at(1, 1),
+				},
+				{
+					Kind:   game.LeveledUpEvent,
+					Piece:  2,
+					From:   at(0, 1),
+					To:     at(0, 1),
+					Life:   2,
+					Damage: 2,
+				},
+			},
+		},
+		{
+			name: "simultaneous",
+			mode: game.SimultaneousPlays,
+			plays: map[game.PlayerID]game.Play{
+				game.Player1: {
+					game.NewMove(piece(1), game.South),
+				},
+				game.Player2: {
+					game.NewMove(piece(6), game.North),
+					game.NewMove(piece(5), game.West),
+				},
+			},
+			events: []game.Event{
+				{
+					Kind:  game.MovedEvent,
+					Piece: 5,
+					From:  at(2, 2),
+					To:    at(2, 1),
+				},
+				{
+					Kind:   game.AttackedEvent,
+					Piece:  1,
+					Target: 6,
+					From:   at(0, 0),
+					To:     at(2, 0),
+					Life:   0,
+					Damage: 1,
+				},
+				{
+					Kind:   game.AttackedEvent,
+					Piece:  6,
+					Target: 1,
+					From:   at(2, 0),
+					To:     at(0, 0),
+					Life:   0,
+					Damage: 1,
+				},
+				{
+					Kind:  game.DestroyedEvent,
+					Piece: 1,
+					From:  at(0, 0),
+					To:    at(0, 0),
+				},
+				{
+					Kind:  game.DestroyedEvent,
+					Piece: 6,
+					From:  at(2, 0),
+					To:    at(2, 0),
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		s := game.NextStateWithPlays(eventState(c.mode), c.plays)
+		if es := s.Events(); !reflect.DeepEqual(es, c.events) {
+			t.Errorf(
+				"%s: s.Events() = %v, want %v",
+				c.name, es, c.events,
+			)
+		}
+		s = game.NextStateWithPlays(s, nil)
+		if es := s.Events(); len(es) != 0 {
+			t.Errorf(
+				"%s: next s.Events() = %v, want none",
+				c.name, es,
+			)
+		}
+	}
+}
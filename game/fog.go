@@ -0,0 +1,118 @@
+package game
+
+// WithVision returns a copy of the Rules with fog of war where each Player
+// only sees the Cells within the radius of their Pieces.
+//
+// Radiuses are measured in Moves of 1 Cell in any Direction, so a radius of 1
+// sees the Cells around each Piece. Radiuses of zero or less turn fog of war
+// off.
+func (r Rules) WithVision(radius int) Rules {
+	if radius < 0 {
+		radius = 0
+	}
+	r.vision = radius
+	return r
+}
+
+// Vision radius of each Piece or zero if there is no fog of war.
+func (r Rules) Vision() int {
+	return r.vision
+}
+
+// PlayerView returns what the Player with the PlayerID can see of the State.
+//
+// The view is a copy of the State without the enemy Pieces outside of the
+// vision of the Player's Pieces, the Events outside of the vision, the Plays
+// made before it, or the record of earlier positions. The number of Pieces
+// each Player has left is still known, so hidden Pieces are counted by
+// HiddenPieces and Players are eliminated in the view exactly when they are in
+// the State.
+//
+// Players choose their Plays from their views in games with fog of war, so
+// Moves into Cells with hidden enemy Pieces attack them like usual.
+//
+// The State itself is returned if the Rules have no fog of war.
+func (s *State) PlayerView(id PlayerID) *State {
+	if s.Rules().Vision() <= 0 || s.viewer == id {
+		return s
+	}
+	v := clone(s)
+	v.viewer = id
+	v.previous = nil
+	v.lastPlay = nil
+	v.positions = nil
+	for _, other := range s.Rules().PlayerIDs() {
+		if other == id {
+			continue
+		}
+		for _, p := range v.board.PlayerPieces(other) {
+			if !v.IsVisible(v.CellForPiece(p)) {
+				v.destroyPiece(p)
+			}
+		}
+	}
+	v.events = v.visibleEvents(s.events)
+	return v
+}
+
+// Viewer returns the PlayerID of the Player whose PlayerView the State is or
+// NoPlayer if the State shows everything.
+func (s *State) Viewer() PlayerID {
+	return s.viewer
+}
+
+// IsVisible returns true iff the Cell can be seen in the State.
+//
+// Every Cell can be seen in States which aren't a PlayerView.
+func (s *State) IsVisible(c Cell) bool {
+	if s.viewer == NoPlayer {
+		return true
+	}
+	radius := s.Rules().Vision()
+	for _, p := range s.board.PlayerPieces(s.viewer) {
+		pc := s.CellForPiece(p)
+		dr, dc := abs(pc.Row()-c.Row()), abs(pc.Column()-c.Column())
+		if dr <= radius && dc <= radius {
+			return true
+		}
+	}
+	return false
+}
+
+// HiddenPieces returns how many of the Player with the PlayerID's Pieces are
+// left but can't be seen in the State.
+func (s *State) HiddenPieces(id PlayerID) int {
+	if id <= NoPlayer || int(id) >= len(s.piecesAlive) {
+		return 0
+	}
+	return s.piecesAlive[id] - len(s.PlayerPieces(id))
+}
+
+// WithHiddenPieces returns a copy of the State which is the PlayerView of the
+// Player with the viewer PlayerID where each Player has the mapped number of
+// hidden Pieces.
+//
+// This is meant for restoring PlayerViews, such as ones created with
+// NewStateFromInfo from the Pieces in a view. Players with hidden Pieces aren't
+// eliminated.
+func (s *State) WithHiddenPieces(
+	viewer PlayerID,
+	hidden map[PlayerID]int,
+) *State {
+	s = clone(s)
+	s.viewer = viewer
+	s.positions = nil
+	var eliminated []PlayerID
+	for _, id := range s.eliminated {
+		if hidden[id] <= 0 {
+			eliminated = append(eliminated, id)
+		}
+	}
+	s.eliminated = eliminated
+	for id, n := range hidden {
+		if id > NoPlayer && int(id) < len(s.piecesAlive) && n > 0 {
+			s.piecesAlive[id] += n
+		}
+	}
+	return s
+}
@@ -0,0 +1,132 @@
+package game_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// fogState where player 1 has game.Pieces in the top corners of a 5 by 5 board
+// with a vision of 1 and player 2 has a game.Piece next to player 1's
+// top-left game.Piece and another in the bottom-right corner.
+func fogState(p1, p2 game.Player) *game.State {
+	r := game.NewRules(time.Second, 2, 1, 1, 1, 1).
+		WithBoardSize(5, 5).
+		WithVision(1)
+	return game.NewStateFromInfo(
+		r,
+		game.Player1,
+		p1, p2,
+		map[game.Cell]game.Piece{
+			game.NewCell(0, 0): game.NewPiece(1, 1, 1),
+			game.NewCell(0, 4): game.NewPiece(2, 1, 1),
+			game.NewCell(1, 1): game.NewPiece(3, 1, 1),
+			game.NewCell(4, 4): game.NewPiece(4, 1, 1),
+		},
+	)
+}
+
+// TestPlayerView tests that game.State.PlayerView hides the enemy game.Pieces
+// outside of the game.Player's vision but still counts them.
+func TestPlayerView(t *testing.T) {
+	t.Parallel()
+	s := fogState(nil, nil)
+	v := s.PlayerView(game.Player1)
+	cases := []struct {
+		cell    game.Cell
+		visible bool
+		piece   game.PieceID
+	}{
+		{cell: game.NewCell(0, 0), visible: true, piece: 1},
+		{cell: game.NewCell(1, 1), visible: true, piece: 3},
+		{cell: game.NewCell(1, 3), visible: true},
+		{cell: game.NewCell(2, 2), visible: false},
+		{cell: game.NewCell(4, 4), visible: false},
+	}
+	for _, c := range cases {
+		if v.IsVisible(c.cell) != c.visible {
+			t.Errorf(
+				"v.IsVisible(%v) = %v, want %v",
+				c.cell, !c.visible, c.visible,
+			)
+		}
+		if p := v.PieceForCell(c.cell); p.ID() != c.piece {
+			t.Errorf(
+				"v.PieceForCell(%v).ID() = %d, want %d",
+				c.cell, p.ID(), c.piece,
+			)
+		}
+	}
+	if v.Viewer() != game.Player1 {
+		t.Errorf("v.Viewer() = %v, want %v", v.Viewer(), game.Player1)
+	}
+	if n := v.HiddenPieces(game.Player2); n != 1 {
+		t.Errorf("v.HiddenPieces(%v) = %d, want %d", game.Player2, n, 1)
+	}
+	if v.IsOver() {
+		t.Errorf("v.IsOver() = %v, want %v", true, false)
+	}
+	if p := s.PieceForCell(game.NewCell(4, 4)); p.ID() != 4 {
+		t.Errorf(
+			"s.PieceForCell(%v) changed to %v",
+			game.NewCell(4, 4), p,
+		)
+	}
+	plain := game.NewState(game.StandardRules, nil, nil)
+	if plain.PlayerView(game.Player1) != plain {
+		t.Errorf("plain.PlayerView(%v) != plain", game.Player1)
+	}
+}
+
+// TestWithHiddenPieces tests that game.State.WithHiddenPieces restores a
+// game.State.PlayerView where all of the enemy game.Pieces are hidden.
+func TestWithHiddenPieces(t *testing.T) {
+	t.Parallel()
+	r := fogState(nil, nil).Rules()
+	restored := game.NewStateFromInfo(
+		r,
+		game.Player1,
+		nil, nil,
+		map[game.Cell]game.Piece{
+			game.NewCell(0, 0): game.NewPiece(1, 1, 1),
+			game.NewCell(0, 4): game.NewPiece(2, 1, 1),
+		},
+	).WithHiddenPieces(game.Player1, map[game.PlayerID]int{game.Player2: 2})
+	if len(restored.Eliminated()) != 0 || restored.IsOver() {
+		t.Errorf(
+			"restored.Eliminated(), restored.IsOver() = %v, %v, "+
+				"want %v, %v",
+			restored.Eliminated(), restored.IsOver(), nil, false,
+		)
+	}
+	if n := restored.HiddenPieces(game.Player2); n != 2 {
+		t.Errorf(
+			"restored.HiddenPieces(%v) = %d, want %d",
+			game.Player2, n, 2,
+		)
+	}
+}
+
+// peeking game.Player which records whether it could see the bottom-right
+// corner of the board.
+type peeking struct {
+	saw *bool
+}
+
+// Play for peeking.
+func (p peeking) Play(s *game.State) game.Play {
+	*p.saw = s.PieceForCell(game.NewCell(4, 4)) != game.NoPiece
+	return nil
+}
+
+// TestNextStateFog tests that game.NextState gives game.Players their
+// game.State.PlayerView in games with fog of war.
+func TestNextStateFog(t *testing.T) {
+	t.Parallel()
+	saw := true
+	game.NextState(fogState(peeking{saw: &saw}, nil))
+	if saw {
+		t.Errorf("player 1 saw the hidden piece")
+	}
+}
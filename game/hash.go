@@ -0,0 +1,91 @@
+package game
+
+// Hash identifies the position at the State.
+//
+// Positions are the same if the same Pieces are in the same Cells with the
+// same life and damage, the same Player is to play, and, in games with
+// territory control, the same Players own the same Cells. Equal positions
+// always have equal Hashes and different positions have different Hashes with
+// high probability.
+//
+// The Hash is maintained incrementally as Plays are applied, so calling it is
+// cheap.
+func (s *State) Hash() uint64 {
+	return s.hash
+}
+
+// Kinds of features hashed into a State's Hash.
+const (
+	pieceFeature = iota + 1
+	cellFeature
+	playerFeature
+	ownerFeature
+)
+
+// zobrist returns the pseudo-random key for the feature of the given kind with
+// the given values.
+//
+// Keys are derived from the values instead of being looked up in a table so
+// they exist for any board size, life, and damage. Hashes are formed by
+// XOR-ing the keys of all the features of a position, which lets features be
+// added and removed in constant time.
+func zobrist(kind, a, b, c int) uint64 {
+	return mix(mix(mix(mix(uint64(kind))^uint64(a))^uint64(b)) ^ uint64(c))
+}
+
+// mix the bits of x using the SplitMix64 finalizer.
+func mix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// hashPiece returns the key for the Piece's life and damage.
+//
+// NoPiece has a key of 0.
+func hashPiece(p Piece) uint64 {
+	if p.ID() == NoPieceID {
+		return 0
+	}
+	return zobrist(pieceFeature, int(p.ID()), p.Life(), p.Damage())
+}
+
+// hashCell returns the key for the Piece with the PieceID being in the Cell.
+func hashCell(pid PieceID, c Cell) uint64 {
+	return zobrist(cellFeature, int(pid), c.Row(), c.Column())
+}
+
+// hashPlayer returns the key for the Player with the PlayerID being the
+// current Player.
+func hashPlayer(id PlayerID) uint64 {
+	return zobrist(playerFeature, int(id), 0, 0)
+}
+
+// hashOwner returns the key for the Player with the PlayerID owning the Cell.
+//
+// Cells without an owner have a key of 0.
+func hashOwner(c Cell, id PlayerID) uint64 {
+	if id == NoPlayer {
+		return 0
+	}
+	return zobrist(ownerFeature, int(id), c.Row(), c.Column())
+}
+
+// computeHash computes the Hash of the State from scratch.
+func computeHash(s *State) uint64 {
+	h := hashPlayer(s.CurrentPlayer())
+	for _, p := range s.board.Pieces() {
+		h ^= hashPiece(p)
+		if c, ok := s.board.Cell(p.ID()); ok {
+			h ^= hashCell(p.ID(), c)
+		}
+	}
+	for i := 0; i < s.board.height; i++ {
+		for j := 0; j < s.board.width; j++ {
+			c := NewCell(i, j)
+			h ^= hashOwner(c, s.board.Owner(c))
+		}
+	}
+	return h
+}
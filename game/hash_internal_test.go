@@ -0,0 +1,64 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// TestHashIsIncremental tests that the incrementally maintained hash of a State
+// matches the hash computed from scratch throughout random games.
+func TestHashIsIncremental(t *testing.T) {
+	t.Parallel()
+	gen := rand.New(rand.NewSource(1))
+	r := NewRules(30*time.Second, 2, 2, 1, 1, 1)
+	for i := 0; i < 20; i++ {
+		s := NewState(r, nil, nil)
+		for !s.IsOver() {
+			ps := LegalPlays(s)
+			s = NextStateWithPlay(s, ps[gen.Intn(len(ps))])
+			if s.Hash() != computeHash(s) {
+				t.Fatalf(
+					"s.Hash() = %d, want %d at turn %d",
+					s.Hash(), computeHash(s), s.Turn(),
+				)
+			}
+		}
+	}
+}
+
+// TestOutcomesHashCollision tests that outcomes tells positions apart when
+// their Hashes collide and still finds positions and mirror images already
+// added.
+func TestOutcomesHashCollision(t *testing.T) {
+	t.Parallel()
+	s := NewState(NewRules(time.Second, 1, 1, 1, 1, 1), nil, nil)
+	move := func(d Direction) *State {
+		return NextStateWithPlay(s, Play{NewMove(NewPiece(1, 1, 1), d)})
+	}
+	east, west, south := move(East), move(West), move(South)
+	south.hash = east.hash
+	partners, symmetric := mirrorPartners(s)
+	if !symmetric {
+		t.Fatalf("mirrorPartners(s) symmetric = false, want true")
+	}
+	o := newOutcomes(partners, symmetric)
+	cases := []struct {
+		name  string
+		state *State
+		added bool
+	}{
+		{name: "east", state: east, added: true},
+		{name: "colliding south", state: south, added: true},
+		{name: "east again", state: east},
+		{name: "mirrored west", state: west},
+	}
+	for _, c := range cases {
+		if got := o.add(c.state); got != c.added {
+			t.Errorf(
+				"%s: o.add(s) = %v, want %v",
+				c.name, got, c.added,
+			)
+		}
+	}
+}
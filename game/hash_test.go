@@ -0,0 +1,68 @@
+package game_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// BenchmarkHash benchmarks the cost of getting the hash of a game.State.
+func BenchmarkHash(b *testing.B) {
+	s := game.NewState(game.StandardRules, normal1{}, normal2{})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Hash()
+	}
+}
+
+// TestHash tests that game.States with the same position have the same hash
+// no matter how they were reached and game.States with different positions
+// don't.
+func TestHash(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(30*time.Second, 2, 1, 1, 1, 1)
+	s := game.NewState(r, normal1{}, normal2{})
+	p1 := s.Player1Pieces()[0]
+	p2 := s.Player1Pieces()[1]
+	p3 := s.Player2Pieces()[0]
+	a := game.NextStateWithPlay(s, game.Play{
+		game.NewMove(p1, game.South),
+		game.NewMove(p2, game.South),
+	})
+	b := game.NextStateWithPlay(s, game.Play{
+		game.NewMove(p2, game.South),
+		game.NewMove(p1, game.South),
+	})
+	if a.Hash() != b.Hash() {
+		t.Errorf("a.Hash() = %d, want %d", a.Hash(), b.Hash())
+	}
+	c := game.NextStateWithPlay(s, game.Play{game.NewMove(p1, game.South)})
+	if a.Hash() == c.Hash() {
+		t.Errorf("a.Hash() = c.Hash() = %d, want different", a.Hash())
+	}
+	if s.Hash() == game.NextStateWithPlay(s, nil).Hash() {
+		t.Errorf("hash doesn't depend on the current player")
+	}
+	d := game.NextStateWithPlay(c, game.Play{game.NewMove(p3, game.East)})
+	e := game.NextStateWithPlay(
+		game.NextStateWithPlay(s, nil),
+		game.Play{game.NewMove(p3, game.East)},
+	)
+	e = game.NextStateWithPlay(e, game.Play{game.NewMove(p1, game.South)})
+	if d.Hash() == e.Hash() {
+		t.Errorf("d.Hash() = e.Hash() = %d, want different", d.Hash())
+	}
+	f := game.NewStateFromInfo(
+		r, game.Player1, normal1{}, normal2{},
+		map[game.Cell]game.Piece{
+			game.NewCell(0, 1): game.NewPiece(1, 1, 1),
+			game.NewCell(0, 3): game.NewPiece(2, 1, 1),
+			game.NewCell(4, 1): game.NewPiece(3, 1, 1),
+			game.NewCell(4, 3): game.NewPiece(4, 1, 1),
+		},
+	)
+	if f.Hash() != s.Hash() {
+		t.Errorf("f.Hash() = %d, want %d", f.Hash(), s.Hash())
+	}
+}
@@ -0,0 +1,57 @@
+package game
+
+// Step in a game's history made of a State and the Play made from it.
+type Step struct {
+	State *State
+	Play  Play
+}
+
+// WithHistory returns a copy of the State which records the history of every
+// State that follows from it.
+//
+// Recording history keeps every earlier State of the game alive, so it is
+// off by default.
+func (s *State) WithHistory() *State {
+	s = clone(s)
+	s.history = true
+	return s
+}
+
+// Previous returns the State the State followed from or nil if the State
+// doesn't have a recorded history.
+func (s *State) Previous() *State {
+	return s.previous
+}
+
+// LastPlay returns the Play made from the Previous State to reach the State.
+//
+// nil is returned if the State doesn't have a recorded history.
+func (s *State) LastPlay() Play {
+	return s.lastPlay
+}
+
+// History of the game up to the State in the order Plays were made.
+//
+// The History only goes back as far as the State WithHistory was called on.
+func (s *State) History() []Step {
+	var steps []Step
+	for n := s; n.previous != nil; n = n.previous {
+		steps = append(steps, Step{State: n.previous, Play: n.lastPlay})
+	}
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return steps
+}
+
+// Undo the last n Plays made to reach the State and return the State before
+// them.
+//
+// Undoing stops at the earliest recorded State if there are fewer than n
+// recorded Plays.
+func Undo(s *State, n int) *State {
+	for i := 0; i < n && s.previous != nil; i++ {
+		s = s.previous
+	}
+	return s
+}
@@ -0,0 +1,96 @@
+package game_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// TestHistory tests that game.States made with history record every earlier
+// game.State and game.Play and can be undone.
+func TestHistory(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(30*time.Second, 1, 1, 1, 1, 1)
+	s := game.NewState(r, normal1{}, normal2{})
+	if n := game.NextStateWithPlay(s, nil); n.Previous() != nil {
+		t.Errorf("n.Previous() = %v, want %v", n.Previous(), nil)
+	}
+	start := s.WithHistory()
+	p1 := s.Player1Pieces()[0]
+	p2 := s.Player2Pieces()[0]
+	plays := []game.Play{
+		{game.NewMove(p1, game.South)},
+		{game.NewMove(p2, game.West)},
+		nil,
+	}
+	states := []*game.State{start}
+	for _, p := range plays {
+		n := game.NextStateWithPlay(states[len(states)-1], p)
+		states = append(states, n)
+	}
+	end := states[len(states)-1]
+	if end.Turn() != len(plays) {
+		t.Errorf("end.Turn() = %d, want %d", end.Turn(), len(plays))
+	}
+	steps := end.History()
+	if len(steps) != len(plays) {
+		t.Fatalf(
+			"len(end.History()) = %d, want %d",
+			len(steps), len(plays),
+		)
+	}
+	for i, step := range steps {
+		if step.State != states[i] {
+			t.Errorf(
+				"steps[%d].State = %v, want %v",
+				i, step.State, states[i],
+			)
+		}
+		if !reflect.DeepEqual(step.Play, plays[i]) {
+			t.Errorf(
+				"steps[%d].Play = %v, want %v",
+				i, step.Play, plays[i],
+			)
+		}
+	}
+	if !reflect.DeepEqual(end.LastPlay(), plays[len(plays)-1]) {
+		t.Errorf("end.LastPlay() = %v, want %v", end.LastPlay(), nil)
+	}
+	if u := game.Undo(end, 2); u != states[1] || u.Turn() != 1 {
+		t.Errorf("game.Undo(end, 2) = %v, want %v", u, states[1])
+	}
+	if u := game.Undo(end, 10); u != start {
+		t.Errorf("game.Undo(end, 10) = %v, want %v", u, start)
+	}
+	if start.Previous() != nil {
+		t.Errorf(
+			"start.Previous() = %v, want %v",
+			start.Previous(), nil,
+		)
+	}
+}
+
+// TestHistoryKeepsReusedPlays tests that game.States made with history keep
+// the game.Plays game.LegalPlaySeq made them with after the game.Plays are
+// reused.
+func TestHistoryKeepsReusedPlays(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(30*time.Second, 2, 1, 1, 1, 1)
+	s := game.NewState(r, nil, nil).WithHistory()
+	var states []*game.State
+	var plays []game.Play
+	for p := range game.LegalPlaySeq(s) {
+		states = append(states, game.NextStateWithPlay(s, p))
+		plays = append(plays, append(game.Play{}, p...))
+	}
+	for i, n := range states {
+		if !reflect.DeepEqual(n.LastPlay(), plays[i]) {
+			t.Errorf(
+				"states[%d].LastPlay() = %v, want %v",
+				i, n.LastPlay(), plays[i],
+			)
+		}
+	}
+}
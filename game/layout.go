@@ -0,0 +1,134 @@
+package game
+
+import "fmt"
+
+// Layout assigns Cells on the board to each Player.
+//
+// Layouts are used both for the Cells each Player's Pieces start in and for
+// each Player's home zone during a deployment phase.
+//
+// Layout is immutable once created.
+type Layout struct {
+	cells [][]Cell
+}
+
+// NewLayout where each Player with a PlayerID is assigned the Cells in order.
+func NewLayout(cells map[PlayerID][]Cell) *Layout {
+	l := &Layout{cells: make([][]Cell, MaxPlayerCount)}
+	for id, cs := range cells {
+		if id <= NoPlayer || int(id) > MaxPlayerCount {
+			continue
+		}
+		l.cells[id-1] = append([]Cell{}, cs...)
+	}
+	return l
+}
+
+// ParseLayout from a map in the form accepted by ParseTerrain.
+//
+// Cells marked with a Player's number, such as 1 for Player one, are assigned
+// to that Player in reading order. All other Cells are left unassigned. An
+// error is returned if a character other than a Player's number, OpenSymbol,
+// or BlockedSymbol is used.
+func ParseLayout(rows []string) (*Layout, error) {
+	cells := make(map[PlayerID][]Cell)
+	for i, row := range rows {
+		for j, x := range []rune(row) {
+			if id, ok := layoutPlayer(x); ok {
+				cells[id] = append(cells[id], NewCell(i, j))
+				continue
+			}
+			if x != OpenSymbol && x != BlockedSymbol {
+				return nil, fmt.Errorf(
+					"invalid layout symbol %q at row %d "+
+						"column %d",
+					x, i, j,
+				)
+			}
+		}
+	}
+	return NewLayout(cells), nil
+}
+
+// layoutPlayer returns the PlayerID marked by the layout symbol and true if the
+// symbol is a Player's number.
+func layoutPlayer(x rune) (PlayerID, bool) {
+	if x < '1' || x >= '1'+MaxPlayerCount {
+		return NoPlayer, false
+	}
+	return PlayerID(x - '0'), true
+}
+
+// Cells assigned to the Player with the PlayerID in order.
+//
+// nil Layouts assign no Cells.
+func (l *Layout) Cells(id PlayerID) []Cell {
+	if l == nil || id <= NoPlayer || int(id) > len(l.cells) {
+		return nil
+	}
+	return append([]Cell(nil), l.cells[id-1]...)
+}
+
+// Contains returns true iff the Cell is assigned to the Player with the
+// PlayerID.
+func (l *Layout) Contains(id PlayerID, c Cell) bool {
+	for _, x := range l.Cells(id) {
+		if x == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Map of the Layout for a board with the given width and height in the form
+// accepted by ParseLayout.
+//
+// Cells assigned to more than 1 Player are marked for the last one.
+func (l *Layout) Map(w, h int) []string {
+	rows := make([][]rune, h)
+	for i := range rows {
+		rows[i] = make([]rune, w)
+		for j := range rows[i] {
+			rows[i][j] = OpenSymbol
+		}
+	}
+	for i := 0; i < MaxPlayerCount; i++ {
+		for _, c := range l.Cells(PlayerID(i + 1)) {
+			if c.Row() >= 0 && c.Row() < h &&
+				c.Column() >= 0 && c.Column() < w {
+				rows[c.Row()][c.Column()] = rune('1' + i)
+			}
+		}
+	}
+	out := make([]string, h)
+	for i, row := range rows {
+		out[i] = string(row)
+	}
+	return out
+}
+
+// SideZones returns a Layout which assigns each Player the given number of rows
+// or columns along their side of the board as described for NewState.
+//
+// Players three and four aren't assigned the Cells along the top and bottom
+// which belong to Players one and two.
+func SideZones(r Rules, depth int) *Layout {
+	w, h := r.BoardWidth(), r.BoardHeight()
+	cells := make(map[PlayerID][]Cell)
+	for i := 0; i < h; i++ {
+		for j := 0; j < w; j++ {
+			c := NewCell(i, j)
+			switch {
+			case i < depth:
+				cells[Player1] = append(cells[Player1], c)
+			case i >= h-depth:
+				cells[Player2] = append(cells[Player2], c)
+			case j < depth:
+				cells[Player3] = append(cells[Player3], c)
+			case j >= w-depth:
+				cells[Player4] = append(cells[Player4], c)
+			}
+		}
+	}
+	return NewLayout(cells)
+}
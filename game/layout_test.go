@@ -0,0 +1,152 @@
+package game_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// TestParseLayout tests that game.ParseLayout assigns the game.Cells marked
+// with each game.Player's number in reading order, rejects unknown symbols,
+// and that game.Layout maps back to the same rows.
+func TestParseLayout(t *testing.T) {
+	t.Parallel()
+	at := game.NewCell
+	rows := []string{
+		"2.1",
+		"1#2",
+	}
+	l, err := game.ParseLayout(rows)
+	if err != nil {
+		t.Fatalf("game.ParseLayout(rows) error = %v, want %v", err, nil)
+	}
+	cases := []struct {
+		id   game.PlayerID
+		want []game.Cell
+	}{
+		{
+			id:   game.Player1,
+			want: []game.Cell{at(0, 2), at(1, 0)},
+		},
+		{
+			id:   game.Player2,
+			want: []game.Cell{at(0, 0), at(1, 2)},
+		},
+		{id: game.Player3, want: nil},
+	}
+	for _, c := range cases {
+		if got := l.Cells(c.id); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("l.Cells(%v) = %v, want %v", c.id, got, c.want)
+		}
+	}
+	want := []string{"2.1", "1.2"}
+	if m := l.Map(3, 2); !reflect.DeepEqual(m, want) {
+		t.Errorf("l.Map(3, 2) = %v, want %v", m, want)
+	}
+	if _, err := game.ParseLayout([]string{".5."}); err == nil {
+		t.Errorf("game.ParseLayout(rows) error = %v, want error", err)
+	}
+	var none *game.Layout
+	if none.Contains(game.Player1, at(0, 0)) {
+		t.Errorf("nil game.Layout contains game.Cells")
+	}
+}
+
+// TestNewStateWithLayout tests that game.NewState places game.Pieces in the
+// game.Cells of a game.Layout set with game.Rules.WithMap.
+func TestNewStateWithLayout(t *testing.T) {
+	t.Parallel()
+	at := game.NewCell
+	r, err := game.NewRules(time.Second, 2, 1, 1, 1, 1).WithMap([]string{
+		"..1",
+		"2#.",
+		"1.2",
+	})
+	if err != nil {
+		t.Fatalf("r.WithMap(rows) error = %v, want %v", err, nil)
+	}
+	if r.Layout() == nil {
+		t.Fatalf("r.Layout() = %v, want a game.Layout", r.Layout())
+	}
+	if r.IsBlocked(at(0, 2)) {
+		t.Errorf("r.IsBlocked(%v) = %v, want %v", at(0, 2),
+			true, false)
+	}
+	s := game.NewState(r, nil, nil)
+	cases := []struct {
+		piece game.PieceID
+		want  game.Cell
+	}{
+		{piece: 1, want: at(0, 2)},
+		{piece: 2, want: at(2, 0)},
+		{piece: 3, want: at(1, 0)},
+		{piece: 4, want: at(2, 2)},
+	}
+	for _, c := range cases {
+		p := s.PieceForCell(c.want)
+		if p == game.NoPiece || p.ID() != c.piece {
+			t.Errorf(
+				"s.PieceForCell(%v) = %v, want piece %d",
+				c.want, p, c.piece,
+			)
+		}
+	}
+	if s.IsDeploying() {
+		t.Errorf("s.IsDeploying() = %v, want %v", true, false)
+	}
+}
+
+// TestRulesWithCheckedLayout tests that game.Rules.WithCheckedLayout and
+// game.Rules.WithMap reject game.Layouts which don't give every game.Piece its
+// own open game.Cell on the board.
+func TestRulesWithCheckedLayout(t *testing.T) {
+	t.Parallel()
+	at := game.NewCell
+	r := game.NewRules(time.Second, 2, 1, 1, 1, 1).
+		WithBoardSize(3, 3).
+		WithTerrain(game.NewTerrain(at(1, 1)))
+	top := []game.Cell{at(0, 0), at(0, 2)}
+	cases := []struct {
+		name  string
+		cells []game.Cell
+		valid bool
+	}{
+		{
+			name:  "valid",
+			cells: []game.Cell{at(2, 0), at(2, 2)},
+			valid: true,
+		},
+		{name: "too few", cells: []game.Cell{at(2, 0)}},
+		{name: "duplicate", cells: []game.Cell{at(2, 0), at(2, 0)}},
+		{name: "shared", cells: []game.Cell{at(2, 0), at(0, 0)}},
+		{name: "off the board", cells: []game.Cell{at(2, 0), at(3, 0)}},
+		{name: "blocked", cells: []game.Cell{at(2, 0), at(1, 1)}},
+	}
+	for _, c := range cases {
+		l := game.NewLayout(map[game.PlayerID][]game.Cell{
+			game.Player1: top,
+			game.Player2: c.cells,
+		})
+		got, err := r.WithCheckedLayout(l)
+		if c.valid && (err != nil || got.Layout() != l) {
+			t.Errorf(
+				"%s: r.WithCheckedLayout(l) error = %v, "+
+					"want %v",
+				c.name, err, nil,
+			)
+		}
+		if !c.valid && (err == nil || got.Layout() != nil) {
+			t.Errorf(
+				"%s: r.WithCheckedLayout(l) error = %v, "+
+					"want error",
+				c.name, err,
+			)
+		}
+	}
+	rows := []string{"#1.", "...", "..2"}
+	if _, err := game.StandardRules.WithMap(rows); err == nil {
+		t.Errorf("r.WithMap(%v) error = %v, want error", rows, err)
+	}
+}
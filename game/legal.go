@@ -1,82 +1,380 @@
 package game
 
-import "sync"
-
-const (
-	// TODO: Update this as a proportion to the legal moves space.
-	bufferSize = 100
-	// TODO: Update this to a number propertional to the available logical
-	// CPUs.
-	readers = 10
+import (
+	"context"
+	"fmt"
+	"iter"
+	"runtime"
+	"sort"
+	"sync"
 )
 
 // LegalPlays returns all the legal Plays for the State's current Player.
+//
+// The Plays are in the order LegalPlaySeq makes them.
 func LegalPlays(s *State) []Play {
-	bs := bucketByPiece(s)
-	for i := range bs {
-		bs[i] = append(bs[i], NoMove)
+	var ps []Play
+	for p := range LegalPlaySeq(s) {
+		ps = append(ps, append(Play(nil), p...))
 	}
-	return legalCombinations(s, bs)
+	return ps
+}
+
+// LegalPlaySeq returns a sequence which makes the legal Plays for the State's
+// current Player one at a time without finding the rest first.
+//
+// Stopping the sequence early skips the Plays which haven't been made yet.
+// Each Piece tries its sequences of Moves in the order they're found and tries
+// not moving last. The first Piece's sequences change the slowest.
+//
+// The Play is reused between Plays, so it must be copied to be kept after the
+// next Play is made. NextStateWithPlay copies the Plays States with history
+// keep, so the Play can be passed to it directly.
+func LegalPlaySeq(s *State) iter.Seq[Play] {
+	return LegalPlaySeqBy(s, nil)
 }
 
-// LegalPlaysPipe is a pipe which outputs legal game.Plays.
+// LegalPlaySeqBy is LegalPlaySeq where each Piece tries its sequences of Moves,
+// including not moving as an empty Play, in the order less sorts them.
+//
+// Moves which are more likely to be good can be tried first so searches which
+// stop early see them.
+func LegalPlaySeqBy(s *State, less func(a, b Play) bool) iter.Seq[Play] {
+	return func(yield func(Play) bool) {
+		newPlayEnumerator(s, less).walk(nil, yield)
+	}
+}
+
+// LegalPlaysPipe is LegalPlaysPipeContext which can't be stopped early and
+// makes the Plays in any order.
+//
+// The pipe must be read until it's closed.
 func LegalPlaysPipe(s *State) chan Play {
-	bs := bucketByPiece(s)
-	for i := range bs {
-		bs[i] = append(bs[i], NoMove)
+	return LegalPlaysPipeContext(context.Background(), s, false)
+}
+
+// LegalPlaysPipeContext is a pipe which outputs the legal Plays for the
+// State's current Player found by a worker for each of the logical CPUs Go
+// uses.
+//
+// The Plays are in the order LegalPlaySeq makes them iff ordered is true.
+// Otherwise they're in whatever order the workers find them, which is faster.
+// The pipe is closed once every Play is output or the context.Context is done.
+// Done context.Contexts stop the workers, so the context.Context must be
+// cancelled to stop reading early.
+func LegalPlaysPipeContext(
+	ctx context.Context,
+	s *State,
+	ordered bool,
+) chan Play {
+	workers := runtime.GOMAXPROCS(0)
+	e := newPlayEnumerator(s, nil)
+	// Several prefixes per worker keep workers busy when some prefixes
+	// have far more Plays than others.
+	prefixes := e.prefixes(4 * workers)
+	ps := make(chan Play, workers)
+	outs := make([]chan Play, len(prefixes))
+	for i := range outs {
+		outs[i] = ps
+		if ordered {
+			outs[i] = make(chan Play, workers)
+		}
+	}
+	send := func(out chan Play, p Play) bool {
+		select {
+		case out <- p:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				e.walk(prefixes[i], func(p Play) bool {
+					p = append(Play(nil), p...)
+					return send(outs[i], p)
+				})
+				if ordered {
+					close(outs[i])
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(next)
+		for i := range prefixes {
+			select {
+			case next <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	if !ordered {
+		go func() {
+			wg.Wait()
+			close(ps)
+		}()
+		return ps
+	}
+	// Prefixes which are never handed out once the context.Context is done
+	// leave their outputs open, so the merger stops waiting on them then.
+	receive := func(out chan Play) (Play, bool) {
+		select {
+		case p, ok := <-out:
+			return p, ok
+		case <-ctx.Done():
+			return nil, false
+		}
 	}
-	ps := make(chan Play, bufferSize)
 	go func() {
-		var wg sync.WaitGroup
-		cs := combinationPipe(s, bs)
-		for i := 0; i < readers; i++ {
-			wg.Add(1)
-			go func() {
-				for combo := range cs {
-					if IsLegalPlay(s, combo) {
-						ps <- removeMove(NoMove, combo)
-					}
+		defer close(ps)
+		for _, out := range outs {
+			for p, ok := receive(out); ok; p, ok = receive(out) {
+				if !send(ps, p) {
+					return
 				}
-				wg.Done()
-			}()
+			}
 		}
-		wg.Wait()
-		close(ps)
 	}()
 	return ps
 }
 
+// playEnumerator enumerates the legal Plays made of a sequence of Moves from
+// each of the buckets of the current Player's Pieces.
+//
+// Plays are legal iff no 2 Pieces' sequences enter the same Cell.
+type playEnumerator struct {
+	buckets [][]Play
+	entered [][][]Cell
+	moves   int
+	width   int
+	height  int
+}
+
+// newPlayEnumerator for the State where the sequences of each bucket are
+// sorted by less if it isn't nil.
+func newPlayEnumerator(s *State, less func(a, b Play) bool) *playEnumerator {
+	e := &playEnumerator{
+		buckets: bucketByPiece(s),
+		width:   s.Rules().BoardWidth(),
+		height:  s.Rules().BoardHeight(),
+	}
+	e.entered = make([][][]Cell, len(e.buckets))
+	for i, bucket := range e.buckets {
+		e.moves += s.allowance(bucket[0][0].Piece().ID())
+		if less != nil {
+			sortSequences(bucket, less)
+		}
+		e.entered[i] = make([][]Cell, len(bucket))
+		for j, seq := range bucket {
+			e.entered[i][j] = enteredCells(s, seq)
+		}
+	}
+	return e
+}
+
+// prefixes returns the indices of every combination of sequences from the
+// fewest first buckets with at least n combinations or all the buckets in the
+// order walk makes them.
+func (e *playEnumerator) prefixes(n int) [][]int {
+	prefixes := [][]int{nil}
+	for i := 0; i < len(e.buckets) && len(prefixes) < n; i++ {
+		var longer [][]int
+		for _, prefix := range prefixes {
+			for j := range e.buckets[i] {
+				prefix := append([]int(nil), prefix...)
+				longer = append(longer, append(prefix, j))
+			}
+		}
+		prefixes = longer
+	}
+	return prefixes
+}
+
+// walk calls yield with every legal Play starting with the sequences at the
+// indices of the prefix in the first buckets until yield returns false.
+//
+// false is returned iff yield returned false.
+func (e *playEnumerator) walk(prefix []int, yield func(Play) bool) bool {
+	owners := make([]int, e.width*e.height)
+	play := make(Play, 0, e.moves)
+	for i, j := range prefix {
+		if !claimCells(owners, e.width, e.entered[i][j], i+1) {
+			return true
+		}
+		play = append(play, e.buckets[i][j]...)
+	}
+	var walk func(i int) bool
+	walk = func(i int) bool {
+		if i == len(e.buckets) {
+			return yield(play)
+		}
+		for j, seq := range e.buckets[i] {
+			cells := e.entered[i][j]
+			if !claimCells(owners, e.width, cells, i+1) {
+				releaseCells(owners, e.width, cells, i+1)
+				continue
+			}
+			play = append(play, seq...)
+			more := walk(i + 1)
+			play = play[:len(play)-len(seq)]
+			releaseCells(owners, e.width, cells, i+1)
+			if !more {
+				return false
+			}
+		}
+		return true
+	}
+	return walk(len(prefix))
+}
+
+// sortSequences of Moves in the order less sorts them.
+func sortSequences(seqs []Play, less func(a, b Play) bool) {
+	sort.SliceStable(seqs, func(i, j int) bool {
+		return less(seqs[i], seqs[j])
+	})
+}
+
+// enteredCells returns the Cells the Moves of a single Piece enter in order.
+//
+// Pieces which attack still enter the Cell they attack for the purpose of
+// stopping other Pieces from moving into it, as in IsLegalPlay.
+func enteredCells(s *State, seq Play) []Cell {
+	if len(seq) == 0 {
+		return nil
+	}
+	cells := make([]Cell, len(seq))
+	from := s.CellForPiece(seq[0].Piece())
+	for i, m := range seq {
+		cells[i] = offsetCell(from, m.Offset())
+		from = stepTo(s, m, from)
+	}
+	return cells
+}
+
+// claimCells marks the Cells on a board of the given width as entered by the
+// owner and returns false iff another owner already entered one.
+func claimCells(owners []int, w int, cells []Cell, owner int) bool {
+	for _, c := range cells {
+		i := c.Row()*w + c.Column()
+		if owners[i] != 0 && owners[i] != owner {
+			return false
+		}
+		owners[i] = owner
+	}
+	return true
+}
+
+// releaseCells unmarks the Cells on a board of the given width the owner
+// entered.
+func releaseCells(owners []int, w int, cells []Cell, owner int) {
+	for _, c := range cells {
+		if i := c.Row()*w + c.Column(); owners[i] == owner {
+			owners[i] = 0
+		}
+	}
+}
+
 // IsLegalPlay returns true iff the Play is legal at the current State.
 //
 // A Play is legal iff all Moves in the play are legal after performing the
-// Moves preceding them and the same Piece doesnt move more than once.
+// Moves preceding them, no Piece moves more times than its Class allows, and
+// no 2 Pieces move into the same Cell.
+//
+// Pieces which attack an enemy Piece stay where they are, so their next Move
+// starts from there.
 func IsLegalPlay(s *State, p Play) bool {
-	used := make([]bool, 2*s.Rules().PieceCount())
-	cm := newCellMap(s.Rules().BoardSize())
-	for _, m := range p {
-		if !IsLegalMove(s, m) || used[m.Piece().ID()-1] {
-			return false
+	return len(checkPlay(s, p, false)) == 0
+}
+
+// checkPlay returns the MoveErrors of the Moves in the Play which make it
+// illegal as described by IsLegalPlay.
+//
+// Only the first MoveError is returned unless all is true. Moves which are
+// rejected aren't made when checking the Moves after them.
+func checkPlay(s *State, p Play, all bool) []MoveError {
+	n := s.Rules().PieceCount() * s.Rules().PlayerCount()
+	used := make([]int, n)
+	at := make([]Cell, n)
+	w := s.Rules().BoardWidth()
+	entered := make([]PieceID, w*s.Rules().BoardHeight())
+	var errs []MoveError
+	for i, m := range p {
+		pid := m.Piece().ID()
+		var from Cell
+		reason := checkPiece(s, m.Piece())
+		if reason == "" {
+			from = at[pid-1]
+			if used[pid-1] == 0 {
+				from = s.CellForPiece(m.Piece())
+			}
+			reason = checkStep(s, m, from)
 		}
-		c := nextCell(s.CellForPiece(m.Piece()), m.Direction())
-		pid, ok := cm.Get(c)
-		if ok && s.playerForPieceID(pid) == s.CurrentPlayer() {
-			return false
+		if reason == "" && used[pid-1] >= s.allowance(pid) {
+			reason = "moves more times than its class allows"
+		}
+		c := offsetCell(from, m.Offset())
+		if reason == "" {
+			other := entered[c.Row()*w+c.Column()]
+			if other != NoPieceID && other != pid &&
+				s.playerForPieceID(other) == s.CurrentPlayer() {
+				reason = fmt.Sprintf(
+					"moves where piece %d already moved",
+					other,
+				)
+			}
+		}
+		if reason != "" {
+			errs = append(errs, MoveError{
+				Index:  i,
+				Move:   m,
+				Reason: reason,
+			})
+			if !all {
+				return errs
+			}
+			continue
 		}
-		used[m.Piece().ID()-1] = true
-		cm.Set(c, m.Piece().ID())
+		used[pid-1]++
+		entered[c.Row()*w+c.Column()] = pid
+		at[pid-1] = stepTo(s, m, from)
 	}
-	return true
+	return errs
+}
+
+// checkPiece returns why the Piece can't be moved by the current Player or ""
+// if it can.
+//
+// The Piece must be on the board, belong to the current Player, and have the
+// same life and damage as in the State.
+func checkPiece(s *State, p Piece) string {
+	actual, ok := s.board.Piece(p.ID())
+	switch {
+	case s.PlayerForPiece(p) == NoPlayer || !ok:
+		return "moves a piece which isn't on the board"
+	case s.PlayerForPiece(p) != s.CurrentPlayer():
+		return "moves another player's piece"
+	case p.Life() != actual.Life() || p.Damage() != actual.Damage():
+		return fmt.Sprintf(
+			"gives the piece %d|%d instead of its %d|%d",
+			p.Life(), p.Damage(), actual.Life(), actual.Damage(),
+		)
+	}
+	return ""
 }
 
 // LegalMoves returns all legal Moves for the State's current Player.
 func LegalMoves(s *State) []Move {
 	var ms []Move
 	for _, p := range s.currentPlayerPieces() {
-		if p == NoPiece {
-			continue
-		}
-		for _, d := range Directions() {
-			m := NewMove(p, d)
+		for _, o := range s.Rules().Pattern().offsets {
+			m := NewOffsetMove(p, o)
 			if IsLegalMove(s, m) {
 				ms = append(ms, m)
 			}
@@ -89,119 +387,108 @@ func LegalMoves(s *State) []Move {
 //
 // A Move is legal iff:
 //   - the Move's Piece belongs to the current Player.
+//   - the Move's Offset is allowed by the Rules' Pattern.
 //   - the Move's stays within the confines of the Board.
+//   - the Move doesn't enter a Cell blocked by the Board's Terrain.
 //   - the Move doesn't overlap with any other Board Piece's belonging to the
 //   current Player.
+//   - the Move doesn't slide through a blocked Cell or a Cell with another
+//   Piece in it.
 func IsLegalMove(s *State, m Move) bool {
-	cell := nextCell(s.CellForPiece(m.Piece()), m.Direction())
-	r := cell.Row()
-	c := cell.Column()
-	size := s.Rules().BoardSize()
-	if r < 0 || r >= size || c < 0 || c >= size {
-		return false
-	}
-	if p := s.PieceForCell(cell); s.PlayerForPiece(p) == s.CurrentPlayer() {
-		return false
-	}
-	return s.PlayerForPiece(m.Piece()) == s.CurrentPlayer()
+	return isLegalStep(s, m, s.CellForPiece(m.Piece()))
 }
 
-// bucketByPiece buckets the list of Moves by the Piece that made the Move.
-func bucketByPiece(s *State) [][]Move {
-	buckets := make([][]Move, s.Rules().PieceCount())
-	pc := s.Rules().PieceCount()
-	for _, move := range LegalMoves(s) {
-		id := (int(move.Piece().ID()) - 1) % pc
-		buckets[id] = append(buckets[id], move)
-	}
-	var bucketed [][]Move
-	for _, bucket := range buckets {
-		if len(bucket) != 0 {
-			bucketed = append(bucketed, bucket)
-		}
-	}
-	return bucketed
+// isLegalStep returns true iff the Move is legal at the current State if its
+// Piece were in the Cell.
+//
+// The Piece itself doesn't block the Move.
+func isLegalStep(s *State, m Move, from Cell) bool {
+	return checkStep(s, m, from) == "" &&
+		s.PlayerForPiece(m.Piece()) == s.CurrentPlayer()
 }
 
-// legalCombinations of the buckets returns all combinations with one Move from
-// each bucket using all buckets which represent legal Plays.
-func legalCombinations(s *State, buckets [][]Move) []Play {
-	sizes := make([]int, len(buckets))
-	for i, bucket := range buckets {
-		sizes[i] = len(bucket)
+// checkStep returns why the Move isn't legal at the current State if its
+// Piece were in the Cell or "" if it is, ignoring who owns the Piece.
+func checkStep(s *State, m Move, from Cell) string {
+	pattern := s.Rules().Pattern()
+	if m.Offset() == (Offset{}) || !pattern.Allows(m.Offset()) {
+		return fmt.Sprintf(
+			"moves by %d:%d which the %s pattern doesn't allow",
+			m.Offset().Rows(), m.Offset().Columns(), pattern.Name(),
+		)
 	}
-	n := combinationCount(sizes)
-	combos := make([]Play, 0, n)
-	indices := make([]int, len(buckets))
-	for i := 0; i < n; i++ {
-		p := combinationForIndices(buckets, indices)
-		if IsLegalPlay(s, p) {
-			combos = append(combos, removeMove(NoMove, p))
-		}
-		increment(indices, sizes)
+	cell := offsetCell(from, m.Offset())
+	if !s.Rules().IsOnBoard(cell) {
+		return "moves off the board"
 	}
-	return combos
-}
-
-// combinationPipe is a pipe which outputs game.Plays which aren't necessarily
-// legal.
-func combinationPipe(s *State, buckets [][]Move) chan Play {
-	sizes := make([]int, len(buckets))
-	for i, bucket := range buckets {
-		sizes[i] = len(bucket)
+	if s.Rules().IsBlocked(cell) {
+		return "moves into a blocked cell"
 	}
-	n := combinationCount(sizes)
-	indices := make([]int, len(buckets))
-	combos := make(chan Play, bufferSize)
-	go func() {
-		for i := 0; i < n; i++ {
-			combos <- combinationForIndices(buckets, indices)
-			increment(indices, sizes)
+	p := s.PieceForCell(cell)
+	if p.ID() != m.Piece().ID() &&
+		s.PlayerForPiece(p) == s.CurrentPlayer() {
+		return fmt.Sprintf("is blocked by ally piece %d", p.ID())
+	}
+	for _, o := range pattern.between(m.Offset()) {
+		c := offsetCell(from, o)
+		if !s.Rules().IsOnBoard(c) || s.Rules().IsBlocked(c) {
+			return "slides through a blocked cell"
+		}
+		p := s.PieceForCell(c)
+		if p != NoPiece && p.ID() != m.Piece().ID() {
+			return fmt.Sprintf("slides through piece %d", p.ID())
 		}
-		close(combos)
-	}()
-	return combos
-}
-
-// combinationCount returns the number of combinations of sets with sizes in the
-// list of sizes.
-func combinationCount(sizes []int) int {
-	n := 1
-	for _, size := range sizes {
-		n *= size
 	}
-	return n
+	return ""
 }
 
-// combinationForIndices returns the combination corresponding to the indices
-// into each bucket.
-func combinationForIndices(buckets [][]Move, indices []int) []Move {
-	combo := make([]Move, len(buckets))
-	for i, index := range indices {
-		combo[i] = buckets[i][index]
+// stepTo returns the Cell the Move's Piece ends in after making the Move from
+// the Cell.
+//
+// Pieces which attack an enemy Piece stay in the Cell.
+func stepTo(s *State, m Move, from Cell) Cell {
+	to := offsetCell(from, m.Offset())
+	p := s.PieceForCell(to)
+	if p != NoPiece && p.ID() != m.Piece().ID() {
+		return from
 	}
-	return combo
+	return to
 }
 
-// increment the indices like a number was having 1 added to it with carry and
-// each digit has a base equal to the corresponding element in sizes.
-func increment(indices []int, sizes []int) {
-	i := 0
-	indices[i]++
-	for i < len(indices)-1 && indices[i] >= sizes[i] {
-		indices[i] = 0
-		i++
-		indices[i]++
+// bucketByPiece buckets the sequences of Moves each of the current Player's
+// Pieces can make in a Play by Piece.
+//
+// Each bucket ends with the empty sequence where the Piece doesn't move.
+// Pieces which can't move don't get a bucket.
+func bucketByPiece(s *State) [][]Play {
+	var buckets [][]Play
+	for _, p := range s.currentPlayerPieces() {
+		from := s.CellForPiece(p)
+		bucket := pieceSequences(s, p, from, s.allowance(p.ID()))
+		if len(bucket) != 0 {
+			buckets = append(buckets, append(bucket, nil))
+		}
 	}
+	return buckets
 }
 
-// remove the Move from the list of Moves.
-func removeMove(m Move, ms []Move) []Move {
-	var out []Move
-	for _, cm := range ms {
-		if cm != m {
-			out = append(out, cm)
+// pieceSequences returns all sequences of up to the given number of legal
+// Moves the Piece can make starting from the Cell.
+func pieceSequences(s *State, p Piece, from Cell, n int) []Play {
+	if n <= 0 {
+		return nil
+	}
+	var sequences []Play
+	for _, o := range s.Rules().Pattern().offsets {
+		m := NewOffsetMove(p, o)
+		if !isLegalStep(s, m, from) {
+			continue
+		}
+		sequences = append(sequences, Play{m})
+		rest := pieceSequences(s, p, stepTo(s, m, from), n-1)
+		for _, r := range rest {
+			sequences = append(sequences, append(Play{m}, r...))
 		}
 	}
-	return out
+	return sequences
 }
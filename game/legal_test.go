@@ -1,6 +1,8 @@
 package game_test
 
 import (
+	"context"
+	"fmt"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -19,6 +21,74 @@ func BenchmarkLegalPlays(b *testing.B) {
 	}
 }
 
+// midgame game.State with standard game.Rules where every game.Piece is away
+// from the edges of the board.
+func midgame() *game.State {
+	at := game.NewCell
+	pieces := map[game.Cell]game.Piece{
+		at(4, 3): game.NewPiece(1, 3, 1),
+		at(4, 5): game.NewPiece(2, 3, 1),
+		at(5, 4): game.NewPiece(3, 3, 1),
+		at(6, 3): game.NewPiece(4, 3, 1),
+		at(6, 6): game.NewPiece(5, 3, 1),
+		at(5, 6): game.NewPiece(6, 3, 1),
+		at(7, 4): game.NewPiece(7, 3, 1),
+		at(3, 7): game.NewPiece(8, 3, 1),
+		at(8, 8): game.NewPiece(9, 3, 1),
+		at(5, 2): game.NewPiece(10, 3, 1),
+	}
+	return game.NewStateFromInfo(
+		game.StandardRules,
+		game.Player1,
+		nil, nil,
+		pieces,
+	)
+}
+
+// BenchmarkLegalPlaysMidgame benchmarks the performance of finding the legal
+// game.Plays from a game.State where the game.Pieces have the most room.
+func BenchmarkLegalPlaysMidgame(b *testing.B) {
+	s := midgame()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		game.LegalPlays(s)
+	}
+}
+
+// benchmarkPipe benchmarks the performance of reading every game.Play from
+// game.LegalPlaysPipeContext at the game.State.
+func benchmarkPipe(b *testing.B, s *game.State, ordered bool) {
+	ctx := context.Background()
+	for i := 0; i < b.N; i++ {
+		for range game.LegalPlaysPipeContext(ctx, s, ordered) {
+		}
+	}
+}
+
+// BenchmarkLegalPlaysPipe benchmarks game.LegalPlaysPipeContext at the start
+// of a game.
+func BenchmarkLegalPlaysPipe(b *testing.B) {
+	benchmarkPipe(b, game.NewState(game.StandardRules, nil, nil), false)
+}
+
+// BenchmarkLegalPlaysPipeOrdered benchmarks game.LegalPlaysPipeContext at the
+// start of a game when order is preserved.
+func BenchmarkLegalPlaysPipeOrdered(b *testing.B) {
+	benchmarkPipe(b, game.NewState(game.StandardRules, nil, nil), true)
+}
+
+// BenchmarkLegalPlaysPipeMidgame benchmarks game.LegalPlaysPipeContext at the
+// midgame.
+func BenchmarkLegalPlaysPipeMidgame(b *testing.B) {
+	benchmarkPipe(b, midgame(), false)
+}
+
+// BenchmarkLegalPlaysPipeMidgameOrdered benchmarks
+// game.LegalPlaysPipeContext at the midgame when order is preserved.
+func BenchmarkLegalPlaysPipeMidgameOrdered(b *testing.B) {
+	benchmarkPipe(b, midgame(), true)
+}
+
 // BenchmarkIsLegalPlay benchmarks the cost of determining if a game.Play is
 // legal.
 func BenchmarkIsLegalPlay(b *testing.B) {
@@ -267,6 +337,36 @@ func TestLegalPlays(t *testing.T) {
 	}
 }
 
+// TestLegalPlaysAreOptional tests that game.LegalPlays includes game.Plays
+// where some or none of the current game.Player's game.Pieces move.
+func TestLegalPlaysAreOptional(t *testing.T) {
+	t.Parallel()
+	rules := game.NewRules(30*time.Second, 2, 1, 1, 1, 1)
+	s := game.NewState(rules, normal1{}, normal2{})
+	sizes := make(map[int]int)
+	for _, p := range game.LegalPlays(s) {
+		sizes[len(p)]++
+	}
+	// Both game.Pieces can move 5 ways and can't both move to either of
+	// the 2 game.Cells they share.
+	want := map[int]int{0: 1, 1: 10, 2: 23}
+	if !reflect.DeepEqual(sizes, want) {
+		t.Errorf("game.LegalPlays(s) sizes = %v, want %v", sizes, want)
+	}
+	for p := range game.LegalPlaysPipe(s) {
+		sizes[len(p)]--
+	}
+	for n, count := range sizes {
+		if count != 0 {
+			t.Errorf(
+				"game.LegalPlaysPipe(s) has %d fewer plays of "+
+					"size %d than game.LegalPlays(s)",
+				count, n,
+			)
+		}
+	}
+}
+
 // IsLegalPlay tests if combinations of game.Moves are legal.
 func TestIsLegalPlay(t *testing.T) {
 	t.Parallel()
@@ -747,3 +847,153 @@ func TestIsLegalMove(t *testing.T) {
 		}
 	}
 }
+
+// BenchmarkLegalPlaySeq benchmarks the performance of making the first legal
+// game.Play from a game.State.
+func BenchmarkLegalPlaySeq(b *testing.B) {
+	s := game.NewState(game.StandardRules, normal1{}, normal2{})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range game.LegalPlaySeq(s) {
+			break
+		}
+	}
+}
+
+// TestLegalPlaySeq tests that game.LegalPlaySeq makes every legal game.Play
+// once and can be stopped early.
+func TestLegalPlaySeq(t *testing.T) {
+	t.Parallel()
+	small := game.NewRules(30*time.Second, 3, 1, 1, 1, 1)
+	cases := []struct {
+		name  string
+		rules game.Rules
+	}{
+		{name: "standard", rules: game.StandardRules},
+		{name: "slide", rules: small.WithPattern(game.SlidePattern)},
+		{name: "knight", rules: small.WithPattern(game.KnightPattern)},
+		{
+			name: "scouts",
+			rules: small.WithRoster(
+				game.Player1,
+				game.Scout, game.Scout,
+			),
+		},
+	}
+	for _, c := range cases {
+		s := game.NewState(c.rules, nil, nil)
+		seen := make(map[string]bool)
+		for p := range game.LegalPlaySeq(s) {
+			if !game.IsLegalPlay(s, p) || seen[fmt.Sprint(p)] {
+				t.Errorf(
+					"%s: game.LegalPlaySeq(s) made %v "+
+						"again or illegally",
+					c.name, p,
+				)
+			}
+			seen[fmt.Sprint(p)] = true
+		}
+		n := 0
+		for range game.LegalPlaySeq(s) {
+			n++
+			if n == 3 {
+				break
+			}
+		}
+		if n != 3 {
+			t.Errorf(
+				"%s: stopped after %d plays, want %d",
+				c.name, n, 3,
+			)
+		}
+	}
+}
+
+// TestLegalPlaySeqBy tests that game.LegalPlaySeqBy tries each game.Piece's
+// sequences of game.Moves in the chosen order.
+func TestLegalPlaySeqBy(t *testing.T) {
+	t.Parallel()
+	s := game.NewState(game.StandardRules, normal1{}, normal2{})
+	shortest := func(a, b game.Play) bool {
+		return len(a) < len(b)
+	}
+	for p := range game.LegalPlaySeqBy(s, shortest) {
+		if len(p) != 0 {
+			t.Errorf("first play = %v, want %v", p, game.Play{})
+		}
+		break
+	}
+	for p := range game.LegalPlaySeq(s) {
+		if len(p) != game.StandardRules.PieceCount() {
+			t.Errorf(
+				"len(first play) = %d, want %d",
+				len(p), game.StandardRules.PieceCount(),
+			)
+		}
+		break
+	}
+}
+
+// TestLegalPlaysPipeContext tests that game.LegalPlaysPipeContext outputs
+// the same game.Plays as game.LegalPlays in the same order when asked and
+// closes once its context.Context is cancelled, even if it's ordered.
+func TestLegalPlaysPipeContext(t *testing.T) {
+	t.Parallel()
+	s := midgame()
+	want := game.LegalPlays(s)
+	var got []game.Play
+	ctx := context.Background()
+	for p := range game.LegalPlaysPipeContext(ctx, s, true) {
+		got = append(got, p)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf(
+			"ordered pipe made %d plays, want %d in order",
+			len(got), len(want),
+		)
+	}
+	n := 0
+	for range game.LegalPlaysPipeContext(ctx, s, false) {
+		n++
+	}
+	if n != len(want) {
+		t.Errorf(
+			"unordered pipe made %d plays, want %d",
+			n, len(want),
+		)
+	}
+	for _, ordered := range []bool{false, true} {
+		ctx, cancel := context.WithCancel(context.Background())
+		ps := game.LegalPlaysPipeContext(ctx, s, ordered)
+		<-ps
+		cancel()
+		n := 0
+		for range ps {
+			n++
+		}
+		if n >= len(want)-1 {
+			t.Errorf(
+				"ordered %v: pipe made %d plays after cancel",
+				ordered, n,
+			)
+		}
+	}
+	// Pipes with cancelled context.Contexts may never hand out some
+	// prefixes but must still close.
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 100; i++ {
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			ps := game.LegalPlaysPipeContext(cancelled, s, true)
+			for range ps {
+			}
+		}()
+		select {
+		case <-closed:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("ordered pipe didn't close after cancel")
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package game
 
-// Move of a Piece in a Direction.
+// Move of a Piece by an Offset.
+//
+// Moves of 1 Cell in a Direction are the most common and are made with
+// NewMove.
 type Move struct {
-	piece     Piece
-	direction Direction
+	piece  Piece
+	offset Offset
 }
 
 // NewMove with a valid Piece and Direction.
@@ -11,7 +14,14 @@ type Move struct {
 // NoPiece should not be passed as the Piece and NoDirection shouldn't be passed
 // as the Direction as they're reserved for special uses.
 func NewMove(p Piece, d Direction) Move {
-	return Move{piece: p, direction: d}
+	return Move{piece: p, offset: d.Offset()}
+}
+
+// NewOffsetMove with a valid Piece by the Offset.
+//
+// NewOffsetMove(p, d.Offset()) is the same Move as NewMove(p, d).
+func NewOffsetMove(p Piece, o Offset) Move {
+	return Move{piece: p, offset: o}
 }
 
 // Piece making the Move.
@@ -19,9 +29,20 @@ func (m Move) Piece() Piece {
 	return m.piece
 }
 
-// Direction of the Move.
+// Direction of the Move or NoDirection if the Move isn't 1 Cell in a
+// Direction.
 func (m Move) Direction() Direction {
-	return m.direction
+	for _, d := range Directions() {
+		if d.Offset() == m.offset {
+			return d
+		}
+	}
+	return NoDirection
+}
+
+// Offset the Move moves its Piece by.
+func (m Move) Offset() Offset {
+	return m.offset
 }
 
 // Play is a turn in the game represented by a list of Moves the Player is
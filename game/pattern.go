@@ -0,0 +1,211 @@
+package game
+
+// Offset from one Cell to another in rows and columns.
+//
+// The zero-value Offset stays in the same Cell.
+type Offset struct {
+	rows, columns int
+}
+
+// NewOffset of the given number of rows and columns.
+//
+// Negative rows move north and negative columns move west.
+func NewOffset(rows, columns int) Offset {
+	return Offset{rows: rows, columns: columns}
+}
+
+// Rows moved by the Offset.
+func (o Offset) Rows() int {
+	return o.rows
+}
+
+// Columns moved by the Offset.
+func (o Offset) Columns() int {
+	return o.columns
+}
+
+// offsetCell returns the Cell at the Offset from the Cell.
+func offsetCell(c Cell, o Offset) Cell {
+	return NewCell(c.Row()+o.Rows(), c.Column()+o.Columns())
+}
+
+// Pattern of movement which determines the Offsets a Piece can move by in a
+// single Move.
+//
+// Pieces jump straight to the Cell at the Offset unless the Pattern slides. In
+// Patterns which slide, Moves along a row, column, or diagonal pass through
+// the Cells between, which must be open and empty, so Pieces stop at blockers.
+//
+// Pattern is immutable once created.
+type Pattern struct {
+	name    string
+	slides  bool
+	offsets []Offset
+}
+
+// NewPattern with the name where Pieces can move by the Offsets and slide iff
+// slides is true.
+func NewPattern(name string, slides bool, offsets ...Offset) *Pattern {
+	return &Pattern{
+		name:    name,
+		slides:  slides,
+		offsets: append([]Offset{}, offsets...),
+	}
+}
+
+// Patterns which are commonly used in Rules.
+var (
+	// KingPattern moves 1 Cell in any of the Directions.
+	//
+	// This is the Pattern of Rules which aren't given another one.
+	KingPattern = NewPattern(
+		"king", false,
+		directionOffsets(Directions()...)...,
+	)
+	// OrthogonalPattern moves 1 Cell north, east, south, or west.
+	OrthogonalPattern = NewPattern(
+		"orthogonal", false,
+		directionOffsets(North, East, South, West)...,
+	)
+	// KnightPattern jumps 2 Cells along a row or column and 1 Cell across.
+	KnightPattern = NewPattern(
+		"knight", false,
+		NewOffset(-2, 1), NewOffset(-1, 2),
+		NewOffset(1, 2), NewOffset(2, 1),
+		NewOffset(2, -1), NewOffset(1, -2),
+		NewOffset(-1, -2), NewOffset(-2, -1),
+	)
+	// SlidePattern slides 1 or 2 Cells in any of the Directions.
+	SlidePattern = NewPattern("slide", true, slideOffsets(2)...)
+)
+
+// Patterns returns all the commonly used Patterns.
+func Patterns() []*Pattern {
+	return []*Pattern{
+		KingPattern,
+		OrthogonalPattern,
+		KnightPattern,
+		SlidePattern,
+	}
+}
+
+// directionOffsets returns the Offsets of the Directions in order.
+func directionOffsets(ds ...Direction) []Offset {
+	offsets := make([]Offset, len(ds))
+	for i, d := range ds {
+		offsets[i] = d.Offset()
+	}
+	return offsets
+}
+
+// slideOffsets returns the Offsets of moving 1 up to n Cells in each of the
+// Directions in order.
+func slideOffsets(n int) []Offset {
+	var offsets []Offset
+	for _, d := range Directions() {
+		o := d.Offset()
+		for i := 1; i <= n; i++ {
+			offsets = append(
+				offsets,
+				NewOffset(i*o.Rows(), i*o.Columns()),
+			)
+		}
+	}
+	return offsets
+}
+
+// Name of the Pattern.
+func (p *Pattern) Name() string {
+	return p.name
+}
+
+// Slides returns true iff Moves of the Pattern pass through the Cells between.
+func (p *Pattern) Slides() bool {
+	return p.slides
+}
+
+// Offsets Pieces can move by in order.
+func (p *Pattern) Offsets() []Offset {
+	return append([]Offset{}, p.offsets...)
+}
+
+// Allows returns true iff Pieces can move by the Offset.
+func (p *Pattern) Allows(o Offset) bool {
+	for _, x := range p.offsets {
+		if x == o {
+			return true
+		}
+	}
+	return false
+}
+
+// between returns the Offsets of the Cells passed through when moving by the
+// Offset.
+//
+// Only Offsets along a row, column, or diagonal of Patterns which slide pass
+// through Cells.
+func (p *Pattern) between(o Offset) []Offset {
+	if !p.slides {
+		return nil
+	}
+	n := abs(o.Rows())
+	if c := abs(o.Columns()); n == 0 || (c != 0 && c != n) {
+		n = c
+	}
+	if n < 2 || (o.Rows() != 0 && abs(o.Rows()) != n) {
+		return nil
+	}
+	step := NewOffset(o.Rows()/n, o.Columns()/n)
+	offsets := make([]Offset, n-1)
+	for i := range offsets {
+		offsets[i] = NewOffset(
+			(i+1)*step.Rows(),
+			(i+1)*step.Columns(),
+		)
+	}
+	return offsets
+}
+
+// isPatternStep returns true iff the Rules' Pattern allows the Piece with the
+// PieceID to move by the Offset from the Cell and the Cells it slides through
+// are on the board, open, and empty.
+func isPatternStep(s *State, pid PieceID, from Cell, o Offset) bool {
+	pattern := s.Rules().Pattern()
+	if !pattern.Allows(o) {
+		return false
+	}
+	for _, x := range pattern.between(o) {
+		c := offsetCell(from, x)
+		if !s.Rules().IsOnBoard(c) || s.Rules().IsBlocked(c) {
+			return false
+		}
+		if other, ok := s.board.PieceID(c); ok && other != pid {
+			return false
+		}
+	}
+	return true
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// WithPattern returns a copy of the Rules where Pieces move with the Pattern.
+func (r Rules) WithPattern(p *Pattern) Rules {
+	r.pattern = p
+	return r
+}
+
+// Pattern Pieces move with.
+//
+// This is KingPattern if the Rules weren't given another one.
+func (r Rules) Pattern() *Pattern {
+	if r.pattern == nil {
+		return KingPattern
+	}
+	return r.pattern
+}
@@ -0,0 +1,157 @@
+package game_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// TestPatterns tests that game.LegalMoves and game.IsLegalMove follow the
+// game.Rules' game.Pattern and that game.NextStateWithPlay and
+// game.NextStateWithPlays ignore game.Moves it doesn't allow.
+//
+// Player 1's game.Piece starts in the top-left corner of a 5 by 5 board with
+// a blocked game.Cell to its east and an enemy game.Piece 2 game.Cells to its
+// south.
+func TestPatterns(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		pattern *game.Pattern
+		legal   []game.Offset
+		illegal []game.Offset
+	}{
+		{
+			pattern: game.KingPattern,
+			legal: []game.Offset{
+				game.SouthEast.Offset(),
+				game.South.Offset(),
+			},
+			illegal: []game.Offset{
+				game.East.Offset(),
+				game.NewOffset(2, 0),
+			},
+		},
+		{
+			pattern: game.OrthogonalPattern,
+			legal:   []game.Offset{game.South.Offset()},
+			illegal: []game.Offset{
+				game.SouthEast.Offset(),
+				game.East.Offset(),
+			},
+		},
+		{
+			pattern: game.KnightPattern,
+			legal: []game.Offset{
+				game.NewOffset(1, 2),
+				game.NewOffset(2, 1),
+			},
+			illegal: []game.Offset{
+				game.South.Offset(),
+				game.NewOffset(-1, 2),
+			},
+		},
+		{
+			pattern: game.SlidePattern,
+			legal: []game.Offset{
+				game.South.Offset(),
+				game.NewOffset(2, 0),
+				game.SouthEast.Offset(),
+				game.NewOffset(2, 2),
+			},
+			illegal: []game.Offset{
+				game.East.Offset(),
+				game.NewOffset(0, 2),
+				game.NewOffset(2, 1),
+			},
+		},
+	}
+	base := game.NewRules(time.Second, 1, 1, 1, 1, 1).
+		WithBoardSize(5, 5).
+		WithTerrain(game.NewTerrain(game.NewCell(0, 1)))
+	p := game.NewPiece(1, 1, 1)
+	pieces := map[game.Cell]game.Piece{
+		game.NewCell(0, 0): p,
+		game.NewCell(2, 0): game.NewPiece(2, 1, 1),
+	}
+	for _, c := range cases {
+		r := base.WithPattern(c.pattern)
+		s := game.NewStateFromInfo(r, game.Player1, nil, nil, pieces)
+		if ms := game.LegalMoves(s); len(ms) != len(c.legal) {
+			t.Errorf(
+				"%s: len(game.LegalMoves(s)) = %d, want %d",
+				c.pattern.Name(), len(ms), len(c.legal),
+			)
+		}
+		want := make(map[game.Offset]bool)
+		for _, o := range c.legal {
+			want[o] = true
+		}
+		for _, o := range c.illegal {
+			want[o] = false
+		}
+		for o, legal := range want {
+			m := game.NewOffsetMove(p, o)
+			if game.IsLegalMove(s, m) != legal {
+				t.Errorf(
+					"%s: game.IsLegalMove(s, %v) = %v, "+
+						"want %v",
+					c.pattern.Name(), o, !legal, legal,
+				)
+			}
+		}
+		simultaneous := game.NewStateFromInfo(
+			r.WithPlayMode(game.SimultaneousPlays),
+			game.Player1,
+			nil, nil,
+			pieces,
+		)
+		for _, o := range c.illegal {
+			play := game.Play{game.NewOffsetMove(p, o)}
+			for _, n := range []*game.State{
+				game.NextStateWithPlay(s, play),
+				game.NextStateWithPlays(
+					simultaneous,
+					map[game.PlayerID]game.Play{
+						game.Player1: play,
+					},
+				),
+			} {
+				for c2, want := range pieces {
+					if n.PieceForCell(c2) == want {
+						continue
+					}
+					t.Errorf(
+						"%s: moving by %v changed "+
+							"the piece in %v",
+						c.pattern.Name(), o, c2,
+					)
+				}
+			}
+		}
+	}
+}
+
+// TestOffsetMove tests that game.Moves made by the game.Offset of a
+// game.Direction are the same as game.Moves made in the game.Direction.
+func TestOffsetMove(t *testing.T) {
+	t.Parallel()
+	p := game.NewPiece(1, 1, 1)
+	for _, d := range game.Directions() {
+		m := game.NewOffsetMove(p, d.Offset())
+		if m != game.NewMove(p, d) || m.Direction() != d {
+			t.Errorf(
+				"game.NewOffsetMove(p, %v.Offset()) = %v, "+
+					"want %v",
+				d, m, game.NewMove(p, d),
+			)
+		}
+	}
+	m := game.NewOffsetMove(p, game.NewOffset(2, 1))
+	if m.Direction() != game.NoDirection {
+		t.Errorf(
+			"m.Direction() = %v, want %v",
+			m.Direction(), game.NoDirection,
+		)
+	}
+}
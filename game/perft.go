@@ -0,0 +1,112 @@
+package game
+
+// CountLegalPlays returns the number of legal Plays for the State's current
+// Player, which is len(LegalPlays(s)), without making the Plays.
+//
+// Pieces whose sequences of Moves can't enter the same Cells don't constrain
+// each other, so the Pieces are split into groups which can and the counts of
+// the groups are multiplied. Only the combinations of sequences within a group
+// are tried.
+func CountLegalPlays(s *State) int {
+	e := newPlayEnumerator(s, nil)
+	n := 1
+	owners := make([]int, e.width*e.height)
+	for _, group := range e.groups() {
+		n *= e.count(owners, group)
+	}
+	return n
+}
+
+// Perft returns the number of positions reached by making every sequence of
+// legal Plays depth Plays long from the State.
+//
+// Plays are made with NextStateWithPlay and States where the game IsOver have
+// no Plays. The last Plays are counted with CountLegalPlays instead of being
+// made.
+func Perft(s *State, depth int) int {
+	if depth <= 0 {
+		return 1
+	}
+	if s.IsOver() {
+		return 0
+	}
+	if depth == 1 {
+		return CountLegalPlays(s)
+	}
+	n := 0
+	for p := range LegalPlaySeq(s) {
+		n += Perft(NextStateWithPlay(s, p), depth-1)
+	}
+	return n
+}
+
+// groups returns the indices of the buckets split so that no 2 buckets in
+// different groups have sequences which enter the same Cell.
+func (e *playEnumerator) groups() [][]int {
+	parents := make([]int, len(e.buckets))
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+	firsts := make([]int, e.width*e.height)
+	for i, entered := range e.entered {
+		for _, cells := range entered {
+			for _, c := range cells {
+				k := c.Row()*e.width + c.Column()
+				if firsts[k] == 0 {
+					firsts[k] = i + 1
+					continue
+				}
+				parents[find(i)] = find(firsts[k] - 1)
+			}
+		}
+	}
+	indices := make(map[int]int)
+	var groups [][]int
+	for i := range e.buckets {
+		root := find(i)
+		k, ok := indices[root]
+		if !ok {
+			k = len(groups)
+			indices[root] = k
+			groups = append(groups, nil)
+		}
+		groups[k] = append(groups[k], i)
+	}
+	return groups
+}
+
+// count returns the number of combinations of sequences from the buckets at
+// the indices in the group where no 2 sequences enter the same Cell.
+//
+// The owners must be unclaimed and are left that way.
+func (e *playEnumerator) count(owners []int, group []int) int {
+	if len(group) == 1 {
+		return len(e.buckets[group[0]])
+	}
+	var count func(k int) int
+	count = func(k int) int {
+		i := group[k]
+		n := 0
+		for _, cells := range e.entered[i] {
+			if !claimCells(owners, e.width, cells, i+1) {
+				releaseCells(owners, e.width, cells, i+1)
+				continue
+			}
+			if k == len(group)-1 {
+				n++
+			} else {
+				n += count(k + 1)
+			}
+			releaseCells(owners, e.width, cells, i+1)
+		}
+		return n
+	}
+	return count(0)
+}
@@ -0,0 +1,104 @@
+package game_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// BenchmarkCountLegalPlays benchmarks the performance of counting the legal
+// game.Plays from a game.State where the game.Pieces have the most room.
+func BenchmarkCountLegalPlays(b *testing.B) {
+	s := midgame()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		game.CountLegalPlays(s)
+	}
+}
+
+// TestCountLegalPlays tests that game.CountLegalPlays counts as many
+// game.Plays as game.LegalPlays makes.
+func TestCountLegalPlays(t *testing.T) {
+	t.Parallel()
+	scouts, _ := scoutState()
+	cases := []struct {
+		name  string
+		state *game.State
+	}{
+		{
+			name:  "standard",
+			state: game.NewState(game.StandardRules, nil, nil),
+		},
+		{name: "midgame", state: midgame()},
+		{name: "scouts", state: scouts},
+		{name: "terrain", state: checkState()},
+		{name: "crowded", state: eventState(game.AlternatingPlays)},
+		{
+			name:  "fog",
+			state: fogState(nil, nil).PlayerView(game.Player1),
+		},
+		{
+			name: "empty",
+			state: game.NewStateFromInfo(
+				game.NewRules(time.Second, 1, 1, 1, 1, 1),
+				game.NoPlayer,
+				nil, nil,
+				nil,
+			),
+		},
+	}
+	for _, c := range cases {
+		want := len(game.LegalPlays(c.state))
+		if n := game.CountLegalPlays(c.state); n != want {
+			t.Errorf(
+				"%s: game.CountLegalPlays(s) = %d, want %d",
+				c.name, n, want,
+			)
+		}
+	}
+}
+
+// TestPerft tests that game.Perft counts the positions reached by every
+// sequence of legal game.Plays.
+func TestPerft(t *testing.T) {
+	t.Parallel()
+	s := checkState()
+	two := 0
+	for _, p := range game.LegalPlays(s) {
+		two += len(game.LegalPlays(game.NextStateWithPlay(s, p)))
+	}
+	over := game.NewStateFromInfo(
+		game.NewRules(time.Second, 1, 1, 1, 1, 1),
+		game.Player1,
+		nil, nil,
+		map[game.Cell]game.Piece{
+			game.NewCell(0, 0): game.NewPiece(1, 1, 1),
+		},
+	)
+	cases := []struct {
+		name  string
+		state *game.State
+		depth int
+		want  int
+	}{
+		{name: "depth 0", state: s, depth: 0, want: 1},
+		{
+			name:  "depth 1",
+			state: s,
+			depth: 1,
+			want:  len(game.LegalPlays(s)),
+		},
+		{name: "depth 2", state: s, depth: 2, want: two},
+		{name: "over", state: over, depth: 1, want: 0},
+		{name: "over depth 0", state: over, depth: 0, want: 1},
+	}
+	for _, c := range cases {
+		if n := game.Perft(c.state, c.depth); n != c.want {
+			t.Errorf(
+				"%s: game.Perft(s, %d) = %d, want %d",
+				c.name, c.depth, n, c.want,
+			)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package game
 
+import "context"
+
 // PlayerConstructor constructs a DescribedPlayer without any parameters.
 type PlayerConstructor func() DescribedPlayer
 
@@ -89,6 +91,72 @@ type Player interface {
 	Play(*State) Play
 }
 
+// ContextPlayer is a Player which can be told to stop choosing a Play.
+//
+// PlayContext should return as soon as possible with the Context's error once
+// the Context is done. Other errors mean the Player couldn't choose a Play.
+type ContextPlayer interface {
+	PlayContext(context.Context, *State) (Play, error)
+}
+
+// AsContextPlayer returns the Player as a ContextPlayer.
+//
+// Players which aren't ContextPlayers are adapted so that PlayContext returns
+// once the Context is done even though the Player keeps choosing its Play in
+// the background. That Play is discarded when it arrives.
+func AsContextPlayer(p Player) ContextPlayer {
+	if cp, ok := p.(ContextPlayer); ok {
+		return cp
+	}
+	return contextPlayer{Player: p}
+}
+
+// contextPlayer adapts a Player to be a ContextPlayer.
+type contextPlayer struct {
+	Player
+}
+
+// PlayContext returns the Player's Play or the Context's error if the Context
+// is done first.
+func (p contextPlayer) PlayContext(
+	ctx context.Context,
+	s *State,
+) (Play, error) {
+	return orCancel(ctx, func() (Play, error) {
+		return p.Play(s), nil
+	})
+}
+
+// orCancel returns the result of the function or the Context's error if the
+// Context is done first.
+//
+// The function keeps running in the background once the Context is done and
+// its result is discarded when it arrives.
+func orCancel[T any](ctx context.Context, f func() (T, error)) (T, error) {
+	if ctx.Done() == nil {
+		return f()
+	}
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	type result struct {
+		v   T
+		err error
+	}
+	results := make(chan result, 1)
+	go func() {
+		v, err := f()
+		results <- result{v: v, err: err}
+	}()
+	select {
+	case r := <-results:
+		return r.v, r.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
 // PlayerID identifies a Player in a game.
 type PlayerID int
 
@@ -97,6 +165,8 @@ const (
 	NoPlayer PlayerID = iota // PlayerID zero-value.
 	Player1
 	Player2
+	Player3
+	Player4
 )
 
 // String representation of the PlayerID.
@@ -106,6 +176,10 @@ func (id PlayerID) String() string {
 		return "player 1"
 	case Player2:
 		return "player 2"
+	case Player3:
+		return "player 3"
+	case Player4:
+		return "player 4"
 	default:
 		return "no player"
 	}
@@ -1,7 +1,9 @@
 package game_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/jwowillo/landgrab/game"
 )
@@ -123,6 +125,39 @@ func TestPlayerFactory(t *testing.T) {
 	}
 }
 
+// TestAsContextPlayer tests that game.AsContextPlayer keeps game.ContextPlayers
+// and adapts other game.Players so they stop once the context.Context is done.
+func TestAsContextPlayer(t *testing.T) {
+	t.Parallel()
+	s := game.NewState(game.StandardRules, normal1{}, normal2{})
+	cp := game.AsContextPlayer(normal1{})
+	p, err := cp.PlayContext(context.Background(), s)
+	if p != nil || err != nil {
+		t.Errorf(
+			"cp.PlayContext(ctx, s) = %v, %v, want %v, %v",
+			p, err, nil, nil,
+		)
+	}
+	release := make(chan struct{})
+	defer close(release)
+	cp = game.AsContextPlayer(slow{release: release})
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Millisecond,
+	)
+	defer cancel()
+	if _, err = cp.PlayContext(ctx, s); err != context.DeadlineExceeded {
+		t.Errorf(
+			"cp.PlayContext(ctx, s) error = %v, want %v",
+			err, context.DeadlineExceeded,
+		)
+	}
+	c := cancelling{}
+	if _, ok := game.AsContextPlayer(c).(cancelling); !ok {
+		t.Errorf("game.AsContextPlayer(c) doesn't return c")
+	}
+}
+
 // normal1 game.DescribedPlayer to test game.PlayerFactory with.
 type normal1 struct{}
 
@@ -198,3 +233,21 @@ func (p *special2) Description() string {
 func (p *special2) Play(s *game.State) game.Play {
 	return nil
 }
+
+// cancelling game.ContextPlayer which only returns once its context.Context is
+// done.
+type cancelling struct{}
+
+// Play never returns.
+func (p cancelling) Play(s *game.State) game.Play {
+	select {}
+}
+
+// PlayContext returns once the context.Context is done.
+func (p cancelling) PlayContext(
+	ctx context.Context,
+	s *game.State,
+) (game.Play, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
@@ -0,0 +1,78 @@
+package game
+
+// Position is a State which is changed in place by making Plays and taking
+// them back, for searches which would otherwise copy a State for every Play
+// they look at.
+//
+// Each Play is made exactly like NextStateWithPlay would make it. Plays during
+// the deployment phase, in games with SimultaneousPlays, and from States
+// WithHistory still make a new State since the State they follow from must be
+// kept as it is.
+//
+// Positions aren't safe to use from more than one goroutine at once.
+type Position struct {
+	s       *State
+	journal []boardChange
+}
+
+// Takeback is what's needed to take back a Play made in a Position.
+type Takeback struct {
+	state    State
+	replaced *State
+	journal  int
+}
+
+// NewPosition starting at a copy of the State.
+func NewPosition(s *State) *Position {
+	p := &Position{s: clone(s)}
+	p.s.board.journal = &p.journal
+	return p
+}
+
+// State the Position is at.
+//
+// The State changes as Plays are made and taken back, so it must not be kept
+// past the next change. Snapshot returns a State which can be kept.
+func (p *Position) State() *State {
+	return p.s
+}
+
+// Snapshot returns a copy of the State the Position is at which doesn't
+// change.
+func (p *Position) Snapshot() *State {
+	return clone(p.s)
+}
+
+// Apply the Play to the Position as described for NextStateWithPlay and return
+// the Takeback which reverts it.
+func (p *Position) Apply(play Play) Takeback {
+	s := p.s
+	t := Takeback{state: *s, journal: len(p.journal)}
+	switch {
+	case s.IsOver():
+	case s.IsDeploying() || s.history ||
+		s.Rules().PlayMode() == SimultaneousPlays:
+		t.replaced = s
+		p.s = NextStateWithPlay(clone(s), play)
+		p.s.board.journal = &p.journal
+	default:
+		// Snapshots may share the eliminated Players, so appending to
+		// them must copy them first.
+		n := len(s.eliminated)
+		s.eliminated = s.eliminated[:n:n]
+		makePlay(s, play)
+	}
+	return t
+}
+
+// Revert the Position to where it was before the Play the Takeback came from
+// was applied.
+//
+// Plays must be taken back in the opposite order they were applied.
+func (p *Position) Revert(t Takeback) {
+	if t.replaced != nil {
+		p.s = t.replaced
+	}
+	p.s.board.revert(t.journal)
+	*p.s = t.state
+}
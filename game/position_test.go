@@ -0,0 +1,162 @@
+package game_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// BenchmarkPositionApply benchmarks the performance of applying a game.Play to
+// a game.Position and reverting it.
+func BenchmarkPositionApply(b *testing.B) {
+	s := midgame()
+	p := game.LegalPlays(s)[0]
+	pos := game.NewPosition(s)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pos.Revert(pos.Apply(p))
+	}
+}
+
+// BenchmarkPositionNextState benchmarks the performance of making the same
+// game.Play as BenchmarkPositionApply with game.NextStateWithPlay instead.
+func BenchmarkPositionNextState(b *testing.B) {
+	s := midgame()
+	p := game.LegalPlays(s)[0]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		game.NextStateWithPlay(s, p)
+	}
+}
+
+// describe everything about a game.State which game.Plays change.
+func describe(s *game.State) string {
+	var b strings.Builder
+	fmt.Fprintln(
+		&b,
+		s.Hash(), s.Turn(), s.CurrentPlayer(), s.IsDeploying(),
+		s.IsOver(), s.IsDraw(), s.Winner(), s.Eliminated(),
+		len(s.History()), s.Events(),
+	)
+	for _, p := range s.Pieces() {
+		fmt.Fprintln(&b, p, s.CellForPiece(p))
+	}
+	return b.String()
+}
+
+// TestPosition tests that random games played by applying game.Plays to a
+// game.Position and reverting them go through the same game.States as
+// game.NextStateWithPlay.
+func TestPosition(t *testing.T) {
+	t.Parallel()
+	small := game.NewRules(time.Second, 3, 2, 1, 1, 1).WithBoardSize(4, 4)
+	terrain := small.WithTerrain(game.NewTerrain(game.NewCell(1, 1))).
+		WithRoster(game.Player1, game.Scout, game.Tank).
+		WithRoster(game.Player2, game.Striker)
+	three := small.WithPlayerCount(3).WithBoardSize(5, 5)
+	cases := []struct {
+		name  string
+		state *game.State
+	}{
+		{
+			name:  "standard",
+			state: game.NewState(game.StandardRules, nil, nil),
+		},
+		{name: "small", state: game.NewState(small, nil, nil)},
+		{name: "terrain", state: game.NewState(terrain, nil, nil)},
+		{
+			name: "three players",
+			state: game.NewStateWithPlayers(
+				three,
+				[]game.Player{nil, nil, nil},
+			),
+		},
+		{
+			name: "repetition",
+			state: game.NewState(
+				small.WithRepetitionLimit(2),
+				nil, nil,
+			),
+		},
+		{
+			name: "simultaneous",
+			state: game.NewState(
+				small.WithPlayMode(game.SimultaneousPlays),
+				nil, nil,
+			),
+		},
+		{
+			name: "deployment",
+			state: game.NewState(
+				small.WithHomeZones(game.SideZones(small, 1)),
+				nil, nil,
+			),
+		},
+		{
+			name:  "history",
+			state: game.NewState(small, nil, nil).WithHistory(),
+		},
+		{
+			name: "territory",
+			state: game.NewState(
+				small.WithTerritoryControl(50),
+				nil, nil,
+			),
+		},
+	}
+	for i, c := range cases {
+		rng := rand.New(rand.NewSource(int64(i)))
+		for g := 0; g < 3; g++ {
+			name := fmt.Sprintf("%s: game %d", c.name, g)
+			replay(t, name, c.state, rng)
+		}
+	}
+}
+
+// replay a random game of up to 30 game.Plays from the game.State with
+// game.NextStateWithPlay and with a game.Position and check that they match
+// after every game.Play, after reverting every game.Play, and in snapshots.
+func replay(t *testing.T, name string, s *game.State, rng *rand.Rand) {
+	pos := game.NewPosition(s)
+	states := []*game.State{s}
+	var snapshots []*game.State
+	var takebacks []game.Takeback
+	for turn := 0; turn < 30 && !s.IsOver(); turn++ {
+		ps := game.LegalPlays(s)
+		p := ps[rng.Intn(len(ps))]
+		s = game.NextStateWithPlay(s, p)
+		states = append(states, s)
+		takebacks = append(takebacks, pos.Apply(p))
+		snapshots = append(snapshots, pos.Snapshot())
+		got, want := describe(pos.State()), describe(s)
+		if got != want {
+			t.Fatalf(
+				"%s: turn %d applied %v, want %v",
+				name, turn, got, want,
+			)
+		}
+	}
+	for i := len(takebacks) - 1; i >= 0; i-- {
+		pos.Revert(takebacks[i])
+		got, want := describe(pos.State()), describe(states[i])
+		if got != want {
+			t.Fatalf(
+				"%s: turn %d reverted %v, want %v",
+				name, i, got, want,
+			)
+		}
+	}
+	for i, snapshot := range snapshots {
+		got, want := describe(snapshot), describe(states[i+1])
+		if got != want {
+			t.Errorf(
+				"%s: turn %d snapshot %v, want %v",
+				name, i, got, want,
+			)
+		}
+	}
+}
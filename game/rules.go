@@ -1,42 +1,306 @@
 package game
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 // Rules encapsulates the variable parts of games such as how many Pieces are
 // involved, how much life and damage each Piece has, and how much these
 // increase when they destroy enemy Pieces.
 type Rules struct {
 	timerDuration                                          time.Duration
+	playerCount                                            int
 	pieceCount, damage, life, damageIncrease, lifeIncrease int
+	boardWidth, boardHeight                                int
+	maxTurns, repetitionLimit                              int
+	vision                                                 int
+	territoryControl                                       int
+	timeoutPolicy                                          TimeoutPolicy
+	playMode                                               PlayMode
+	terrain                                                *Terrain
+	layout, homeZones                                      *Layout
+	rosters                                                *rosterTable
+	pattern                                                *Pattern
 }
 
 // NewRules creates Rules with the given values for the variable parts.
+//
+// The Rules are for games between 2 Players. WithPlayerCount changes this.
+//
+// The board is a square with sides of 2 times the piece count plus 1.
+// WithBoardSize changes this.
+//
+// The Rules end games in a draw after DefaultMaxTurns turns or once a position
+// has repeated DefaultRepetitionLimit times. WithMaxTurns and
+// WithRepetitionLimit change these.
 func NewRules(td time.Duration, pc, l, d, li, di int) Rules {
 	return Rules{
-		timerDuration:  td,
-		pieceCount:     pc,
-		damage:         d,
-		life:           l,
-		damageIncrease: di,
-		lifeIncrease:   li,
+		timerDuration:   td,
+		playerCount:     2,
+		pieceCount:      pc,
+		damage:          d,
+		life:            l,
+		damageIncrease:  di,
+		lifeIncrease:    li,
+		boardWidth:      2*pc + 1,
+		boardHeight:     2*pc + 1,
+		maxTurns:        DefaultMaxTurns,
+		repetitionLimit: DefaultRepetitionLimit,
 	}
 }
 
+// Defaults for the draw conditions of Rules created with NewRules.
+const (
+	DefaultMaxTurns        = 500
+	DefaultRepetitionLimit = 3
+)
+
+// MaxPlayerCount is the most Players a game can have.
+const MaxPlayerCount = 4
+
+// WithPlayerCount returns a copy of the Rules for free-for-all games between
+// the given number of Players.
+//
+// Counts below 2 or above MaxPlayerCount are clamped to those limits. Players
+// three and four start on the left and right sides of the board, so the board
+// must be tall enough for their Pieces as checked by ValidateRules.
+func (r Rules) WithPlayerCount(n int) Rules {
+	if n < 2 {
+		n = 2
+	}
+	if n > MaxPlayerCount {
+		n = MaxPlayerCount
+	}
+	r.playerCount = n
+	return r
+}
+
+// WithBoardSize returns a copy of the Rules where the board has the given
+// width and height.
+//
+// Each Player's Pieces start spread along their side of the board, so the
+// board must be big enough for them not to share Cells as checked by
+// ValidateRules.
+func (r Rules) WithBoardSize(w, h int) Rules {
+	r.boardWidth = w
+	r.boardHeight = h
+	return r
+}
+
+// WithTerrain returns a copy of the Rules where the board has the Terrain.
+//
+// Each Player's Pieces start in the same Cells no matter the Terrain, so those
+// Cells must be left open. WithCheckedTerrain rejects Terrain which blocks
+// them.
+func (r Rules) WithTerrain(t *Terrain) Rules {
+	r.terrain = t
+	return r
+}
+
+// WithCheckedTerrain is WithTerrain which returns the Rules unchanged and an
+// error if ValidateRules rejects the Rules with the Terrain, such as when the
+// Terrain blocks a Cell a Piece starts in.
+func (r Rules) WithCheckedTerrain(t *Terrain) (Rules, error) {
+	checked := r.WithTerrain(t)
+	if err := ValidateRules(checked); err != nil {
+		return r, err
+	}
+	return checked, nil
+}
+
+// WithMap returns a copy of the Rules where the board has the size and Terrain
+// of the map.
+//
+// The map has the form accepted by ParseTerrain and must have at least 1 row
+// with all rows having the same length. Cells marked with Players' numbers
+// become the Rules' Layout. An error is also returned if ValidateRules rejects
+// the Rules with the map, such as when a Piece starts in a blocked Cell or a
+// Player is marked in too few Cells.
+func (r Rules) WithMap(rows []string) (Rules, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return r, errors.New("map must have at least 1 row and column")
+	}
+	w := len([]rune(rows[0]))
+	for _, row := range rows {
+		if len([]rune(row)) != w {
+			return r, errors.New("map rows must be the same length")
+		}
+	}
+	t, err := ParseTerrain(rows)
+	if err != nil {
+		return r, err
+	}
+	r = r.WithBoardSize(w, len(rows)).WithTerrain(t)
+	l, err := ParseLayout(rows)
+	if err != nil {
+		return r, err
+	}
+	for _, id := range r.PlayerIDs() {
+		if len(l.Cells(id)) != 0 {
+			r = r.WithLayout(l)
+			break
+		}
+	}
+	return r, ValidateRules(r)
+}
+
+// WithLayout returns a copy of the Rules where each Player's Pieces start in
+// the Cells the Layout assigns them in order of PieceID.
+//
+// Pieces without a Cell in the Layout start where they would without one.
+// WithCheckedLayout rejects Layouts which leave Pieces without a Cell.
+func (r Rules) WithLayout(l *Layout) Rules {
+	r.layout = l
+	return r
+}
+
+// WithCheckedLayout is WithLayout which returns the Rules unchanged and an
+// error if ValidateRules rejects the Rules with the Layout, such as when the
+// Layout assigns a Player too few Cells or the same Cell twice or assigns
+// Cells which are off the board or blocked.
+func (r Rules) WithCheckedLayout(l *Layout) (Rules, error) {
+	checked := r.WithLayout(l)
+	if err := ValidateRules(checked); err != nil {
+		return r, err
+	}
+	return checked, nil
+}
+
+// WithHomeZones returns a copy of the Rules where games start with a
+// deployment phase in which each Player places their Pieces in the Cells the
+// Layout assigns them.
+//
+// A nil Layout means games have no deployment phase.
+func (r Rules) WithHomeZones(l *Layout) Rules {
+	r.homeZones = l
+	return r
+}
+
+// WithTimeoutPolicy returns a copy of the Rules where Players who take longer
+// than the TimerDuration to choose a Play are handled by the TimeoutPolicy.
+func (r Rules) WithTimeoutPolicy(tp TimeoutPolicy) Rules {
+	r.timeoutPolicy = tp
+	return r
+}
+
+// WithPlayMode returns a copy of the Rules where Players make Plays according
+// to the PlayMode.
+func (r Rules) WithPlayMode(pm PlayMode) Rules {
+	r.playMode = pm
+	return r
+}
+
+// WithMaxTurns returns a copy of the Rules where games are drawn after the
+// given number of turns.
+//
+// A non-positive number of turns means games are never drawn by the turn
+// limit.
+func (r Rules) WithMaxTurns(n int) Rules {
+	r.maxTurns = n
+	return r
+}
+
+// WithRepetitionLimit returns a copy of the Rules where games are drawn once
+// the same position has occurred the given number of times.
+//
+// A non-positive limit means games are never drawn by repetition.
+func (r Rules) WithRepetitionLimit(n int) Rules {
+	r.repetitionLimit = n
+	return r
+}
+
 // TimerDuration is the duration for the timer that limits the duration of each
 // turn.
+//
+// A non-positive duration means turns aren't limited.
 func (r Rules) TimerDuration() time.Duration {
 	return r.timerDuration
 }
 
+// TimeoutPolicy decides what happens to a Player who takes longer than the
+// TimerDuration to choose a Play.
+func (r Rules) TimeoutPolicy() TimeoutPolicy {
+	return r.timeoutPolicy
+}
+
+// PlayerCount is the number of Players in the game.
+func (r Rules) PlayerCount() int {
+	return r.playerCount
+}
+
+// PlayerIDs of the Players in the game in the order they play.
+func (r Rules) PlayerIDs() []PlayerID {
+	ids := make([]PlayerID, r.PlayerCount())
+	for i := range ids {
+		ids[i] = PlayerID(i + 1)
+	}
+	return ids
+}
+
+// PlayMode decides whether Players alternate making Plays or make them at the
+// same time.
+func (r Rules) PlayMode() PlayMode {
+	return r.playMode
+}
+
 // PieceCount is the number of Pieces held initially by each Player.
 func (r Rules) PieceCount() int {
 	return r.pieceCount
 }
 
-// BoardSize is 2 times the piece count plus 1 representing the length of 1 side
-// of the board.
+// BoardSize is the length of the longest side of the board.
+//
+// This is the length of every side for the square boards of Rules created with
+// NewRules.
 func (r Rules) BoardSize() int {
-	return r.PieceCount()*2 + 1
+	if r.BoardHeight() > r.BoardWidth() {
+		return r.BoardHeight()
+	}
+	return r.BoardWidth()
+}
+
+// BoardWidth is the number of columns on the board.
+func (r Rules) BoardWidth() int {
+	return r.boardWidth
+}
+
+// BoardHeight is the number of rows on the board.
+func (r Rules) BoardHeight() int {
+	return r.boardHeight
+}
+
+// IsOnBoard returns true iff the Cell is within the confines of the board.
+func (r Rules) IsOnBoard(c Cell) bool {
+	return c.Row() >= 0 && c.Row() < r.BoardHeight() &&
+		c.Column() >= 0 && c.Column() < r.BoardWidth()
+}
+
+// Terrain of the board.
+//
+// nil is returned if the board is an open field.
+func (r Rules) Terrain() *Terrain {
+	return r.terrain
+}
+
+// IsBlocked returns true iff the Cell is blocked by the board's Terrain.
+func (r Rules) IsBlocked(c Cell) bool {
+	return r.terrain.IsBlocked(c)
+}
+
+// Layout of the Cells each Player's Pieces start in.
+//
+// nil is returned if Pieces start spread along each Player's side of the
+// board.
+func (r Rules) Layout() *Layout {
+	return r.layout
+}
+
+// HomeZones each Player places their Pieces in during the deployment phase.
+//
+// nil is returned if games have no deployment phase.
+func (r Rules) HomeZones() *Layout {
+	return r.homeZones
 }
 
 // Life each Piece initially has which defines how much damage it can take
@@ -61,5 +325,68 @@ func (r Rules) DamageIncrease() int {
 	return r.damageIncrease
 }
 
+// MaxTurns is the number of turns after which a game without a winner is a
+// draw.
+//
+// A non-positive value means there is no turn limit.
+func (r Rules) MaxTurns() int {
+	return r.maxTurns
+}
+
+// RepetitionLimit is the number of times the same position can occur before
+// the game is a draw.
+//
+// A non-positive value means repetition never draws a game.
+func (r Rules) RepetitionLimit() int {
+	return r.repetitionLimit
+}
+
+// TimeoutPolicy decides what happens to a Player who runs out of time.
+type TimeoutPolicy int
+
+// TimeoutPolicies which can be used in Rules.
+const (
+	// EmptyPlayOnTimeout makes the Player make an empty Play.
+	EmptyPlayOnTimeout TimeoutPolicy = iota // TimeoutPolicy zero-value.
+	// ForfeitOnTimeout makes the Player forfeit the game.
+	ForfeitOnTimeout
+)
+
+// String representation of the TimeoutPolicy.
+func (tp TimeoutPolicy) String() string {
+	switch tp {
+	case EmptyPlayOnTimeout:
+		return "empty play"
+	case ForfeitOnTimeout:
+		return "forfeit"
+	default:
+		return ""
+	}
+}
+
+// PlayMode decides how Players take turns making Plays.
+type PlayMode int
+
+// PlayModes which can be used in Rules.
+const (
+	// AlternatingPlays has Players make Plays one after another.
+	AlternatingPlays PlayMode = iota // PlayMode zero-value.
+	// SimultaneousPlays has every Player make a Play for the same State
+	// which are all resolved together.
+	SimultaneousPlays
+)
+
+// String representation of the PlayMode.
+func (pm PlayMode) String() string {
+	switch pm {
+	case AlternatingPlays:
+		return "alternating"
+	case SimultaneousPlays:
+		return "simultaneous"
+	default:
+		return ""
+	}
+}
+
 // StandardRules a game is meant to be played by.
 var StandardRules = NewRules(30*time.Second, 5, 3, 1, 1, 1)
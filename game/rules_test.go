@@ -13,6 +13,113 @@ func TestRules(t *testing.T) {
 	t.Parallel()
 	r := game.NewRules(time.Second, 3, 5, 7, 9, 11)
 	testRules(t, r, time.Second, 3, 5, 7, 9, 11)
+	if r.MaxTurns() != game.DefaultMaxTurns {
+		t.Errorf(
+			"r.MaxTurns() = %d, want %d",
+			r.MaxTurns(),
+			game.DefaultMaxTurns,
+		)
+	}
+	if r.RepetitionLimit() != game.DefaultRepetitionLimit {
+		t.Errorf(
+			"r.RepetitionLimit() = %d, want %d",
+			r.RepetitionLimit(),
+			game.DefaultRepetitionLimit,
+		)
+	}
+	if r.TimeoutPolicy() != game.EmptyPlayOnTimeout {
+		t.Errorf(
+			"r.TimeoutPolicy() = %v, want %v",
+			r.TimeoutPolicy(),
+			game.EmptyPlayOnTimeout,
+		)
+	}
+	r = r.WithMaxTurns(13).WithRepetitionLimit(15)
+	r = r.WithTimeoutPolicy(game.ForfeitOnTimeout)
+	testRules(t, r, time.Second, 3, 5, 7, 9, 11)
+	if r.MaxTurns() != 13 {
+		t.Errorf("r.MaxTurns() = %d, want %d", r.MaxTurns(), 13)
+	}
+	if r.RepetitionLimit() != 15 {
+		t.Errorf(
+			"r.RepetitionLimit() = %d, want %d",
+			r.RepetitionLimit(), 15,
+		)
+	}
+	if r.TimeoutPolicy() != game.ForfeitOnTimeout {
+		t.Errorf(
+			"r.TimeoutPolicy() = %v, want %v",
+			r.TimeoutPolicy(),
+			game.ForfeitOnTimeout,
+		)
+	}
+}
+
+// TestRulesWithPlayerCount tests that game.Rules can be for games with more
+// than 2 game.Players and that counts outside the allowed range are clamped.
+func TestRulesWithPlayerCount(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(time.Second, 3, 5, 7, 9, 11)
+	if r.PlayerCount() != 2 {
+		t.Errorf("r.PlayerCount() = %d, want %d", r.PlayerCount(), 2)
+	}
+	cases := []struct {
+		Count, Want int
+	}{
+		{Count: 1, Want: 2},
+		{Count: 3, Want: 3},
+		{Count: 4, Want: 4},
+		{Count: 5, Want: game.MaxPlayerCount},
+	}
+	for _, test := range cases {
+		x := r.WithPlayerCount(test.Count)
+		if x.PlayerCount() != test.Want {
+			t.Errorf(
+				"x.PlayerCount() = %d, want %d",
+				x.PlayerCount(), test.Want,
+			)
+		}
+		ids := x.PlayerIDs()
+		last := game.PlayerID(test.Want)
+		if len(ids) != test.Want || ids[len(ids)-1] != last {
+			t.Errorf(
+				"r.WithPlayerCount(%d).PlayerIDs() = %v",
+				test.Count, ids,
+			)
+		}
+	}
+}
+
+// TestRulesWithBoardSize tests that game.Rules can have rectangular boards.
+func TestRulesWithBoardSize(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(time.Second, 3, 5, 7, 9, 11).WithBoardSize(4, 9)
+	if r.BoardWidth() != 4 {
+		t.Errorf("r.BoardWidth() = %d, want %d", r.BoardWidth(), 4)
+	}
+	if r.BoardHeight() != 9 {
+		t.Errorf("r.BoardHeight() = %d, want %d", r.BoardHeight(), 9)
+	}
+	if r.BoardSize() != 9 {
+		t.Errorf("r.BoardSize() = %d, want %d", r.BoardSize(), 9)
+	}
+	cases := map[game.Cell]bool{
+		game.NewCell(0, 0):  true,
+		game.NewCell(8, 3):  true,
+		game.NewCell(3, 8):  false,
+		game.NewCell(9, 0):  false,
+		game.NewCell(0, 4):  false,
+		game.NewCell(-1, 0): false,
+		game.NoCell:         false,
+	}
+	for c, want := range cases {
+		if r.IsOnBoard(c) != want {
+			t.Errorf(
+				"r.IsOnBoard(%v) = %v, want %v",
+				c, !want, want,
+			)
+		}
+	}
 }
 
 // TestStandardRules test that game.StandardRules has the values defined by the
@@ -41,6 +148,12 @@ func testRules(
 	if r.BoardSize() != 2*pc+1 {
 		t.Errorf("r.BoardSize() = %d, want %d", r.BoardSize(), 2*pc+1)
 	}
+	if r.BoardWidth() != 2*pc+1 || r.BoardHeight() != 2*pc+1 {
+		t.Errorf(
+			"r.BoardWidth(), r.BoardHeight() = %d, %d, want %d, %d",
+			r.BoardWidth(), r.BoardHeight(), 2*pc+1, 2*pc+1,
+		)
+	}
 	if r.Life() != l {
 		t.Errorf("r.Life() = %d, want %d", r.Life(), l)
 	}
@@ -0,0 +1,293 @@
+package game
+
+import "slices"
+
+// NextStateWithPlays returns the next State where every Player makes the Play
+// they're mapped to at the same time.
+//
+// Players left out of the map make an empty Play. Moves of Pieces which don't
+// belong to the Player making the Play are ignored, as are Moves which
+// NextStateWithPlay ignores for not following the Rules' Pattern, leaving the
+// board, or entering blocked Cells. Each Piece makes only its first Move, so
+// ValidateRules rejects Classes which allow more. In games with
+// AlternatingPlays, only the current Player's Play is made.
+//
+// Plays made at the same time are resolved together with these rules:
+//   - a Piece moving into a Cell which is left by the Piece in it follows that
+//     Piece, so Pieces can move in chains and rotations.
+//   - a Piece moving into a Cell held by an enemy Piece which doesn't leave
+//     attacks that Piece and stays where it is.
+//   - two enemy Pieces which swap Cells meet head-on, attack each other, and
+//     stay where they are.
+//   - Pieces moving into the same Cell collide and stay where they are unless
+//     the Cell holds a Piece which isn't moving. Colliding enemy Pieces attack
+//     each other and the enemy Piece in the Cell if it doesn't leave.
+//   - all damage is dealt at once, so two Pieces can destroy each other.
+//     Pieces which survive level up once for every Piece they helped destroy.
+//
+// The State is returned unchanged if the game is already over. Plays made
+// during the deployment phase are handled as in NextStateWithPlay.
+func NextStateWithPlays(s *State, ps map[PlayerID]Play) *State {
+	if s.IsDeploying() || s.Rules().PlayMode() != SimultaneousPlays {
+		return NextStateWithPlay(s, ps[s.CurrentPlayer()])
+	}
+	previous := s
+	s = clone(s)
+	if s.IsOver() {
+		return s
+	}
+	if s.history {
+		s.previous = previous
+		s.lastPlay = slices.Clone(ps[s.CurrentPlayer()])
+	}
+	s.timedOut = nil
+	s.events = nil
+	resolvePlays(s, ps)
+	if s.piecesAlive[s.CurrentPlayer()] == 0 {
+		s.setCurrentPlayer(s.NextPlayer())
+	}
+	s.turn++
+	s.recordPosition()
+	return s
+}
+
+// alivePlayers returns the PlayerIDs of the Players who haven't been
+// eliminated in order.
+func (s *State) alivePlayers() []PlayerID {
+	var ids []PlayerID
+	for _, id := range s.Rules().PlayerIDs() {
+		if s.piecesAlive[id] > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Statuses of a simultaneousMove.
+const (
+	movePending = iota
+	moveMoved
+	moveStayed
+)
+
+// simultaneousMove is a Move being resolved together with other Moves.
+type simultaneousMove struct {
+	piece    Piece
+	from, to Cell
+	status   int
+}
+
+// hit is a Piece attacking another Piece.
+type hit struct {
+	attacker, target PieceID
+}
+
+// resolvePlays made at the same time in the State according to the rules in
+// NextStateWithPlays.
+func resolvePlays(s *State, ps map[PlayerID]Play) {
+	moves := simultaneousMoves(s, ps)
+	targets := make(map[Cell][]*simultaneousMove)
+	for _, m := range moves {
+		if m != nil {
+			targets[m.to] = append(targets[m.to], m)
+		}
+	}
+	moveAt := func(c Cell) (Piece, *simultaneousMove) {
+		pid, ok := s.board.PieceID(c)
+		if !ok {
+			return NoPiece, nil
+		}
+		p, _ := s.board.Piece(pid)
+		return p, moves[pid-1]
+	}
+	var hits []hit
+	attack := func(a, b Piece) {
+		if s.PlayerForPiece(a) != s.PlayerForPiece(b) {
+			h := hit{attacker: a.ID(), target: b.ID()}
+			hits = append(hits, h)
+		}
+	}
+	for _, m := range moves {
+		if m == nil || m.status != movePending {
+			continue
+		}
+		o, om := moveAt(m.to)
+		if om != nil && om.to == m.from &&
+			s.PlayerForPiece(o) != s.PlayerForPiece(m.piece) {
+			m.status, om.status = moveStayed, moveStayed
+			attack(m.piece, o)
+			attack(o, m.piece)
+		}
+	}
+	var collided []*simultaneousMove
+	for c, ms := range targets {
+		o, om := moveAt(c)
+		if o != NoPiece && om == nil {
+			continue
+		}
+		var colliding []*simultaneousMove
+		for _, m := range ms {
+			if m.status == movePending {
+				colliding = append(colliding, m)
+			}
+		}
+		if len(colliding) < 2 {
+			continue
+		}
+		for _, m := range colliding {
+			m.status = moveStayed
+		}
+		collided = append(collided, colliding...)
+		for i, a := range colliding {
+			for _, b := range colliding[i+1:] {
+				attack(a.piece, b.piece)
+				attack(b.piece, a.piece)
+			}
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, m := range moves {
+			if m == nil || m.status != movePending {
+				continue
+			}
+			o, om := moveAt(m.to)
+			switch {
+			case o == NoPiece:
+				m.status = moveMoved
+			case om == nil || om.status == moveStayed:
+				m.status = moveStayed
+				attack(m.piece, o)
+			case om.status == moveMoved:
+				m.status = moveMoved
+			default:
+				continue
+			}
+			changed = true
+		}
+	}
+	// Colliding Pieces also attack the Piece in their Cell if it stayed.
+	for _, m := range collided {
+		if o, om := moveAt(m.to); om != nil && om.status == moveStayed {
+			attack(m.piece, o)
+		}
+	}
+	attackers := make(map[PieceID]bool, len(hits))
+	for _, h := range hits {
+		attackers[h.attacker] = true
+	}
+	// Whatever is left is waiting on itself in a rotation, so it all moves.
+	var pids []PieceID
+	var to []Cell
+	for _, m := range moves {
+		if m == nil {
+			continue
+		}
+		e := Event{Piece: m.piece.ID(), From: m.from, To: m.to}
+		switch {
+		case m.status != moveStayed:
+			pids = append(pids, m.piece.ID())
+			to = append(to, m.to)
+			e.Kind = MovedEvent
+		case attackers[m.piece.ID()]:
+			continue
+		default:
+			e.Kind = BlockedEvent
+		}
+		s.emit(e)
+	}
+	s.relocate(pids, to)
+	handleHits(s, hits)
+}
+
+// simultaneousMoves returns the Moves in the Plays which can be made indexed by
+// their Piece's PieceID minus 1.
+//
+// Moves which stay still, the Rules' Pattern doesn't allow, slide through a
+// blocked or occupied Cell, leave the board, enter blocked Cells, or move a
+// Piece which doesn't belong to the Player or already moved are left out.
+func simultaneousMoves(
+	s *State,
+	ps map[PlayerID]Play,
+) []*simultaneousMove {
+	moves := make(
+		[]*simultaneousMove,
+		s.Rules().PieceCount()*s.Rules().PlayerCount(),
+	)
+	for _, id := range s.alivePlayers() {
+		for _, m := range ps[id] {
+			pid := m.Piece().ID()
+			owner := s.playerForPieceID(pid)
+			if owner != id || moves[pid-1] != nil {
+				continue
+			}
+			p, ok := s.board.Piece(pid)
+			if !ok || m.Offset() == (Offset{}) {
+				continue
+			}
+			from := s.CellForPiece(p)
+			to := offsetCell(from, m.Offset())
+			r := s.Rules()
+			if !r.IsOnBoard(to) || r.IsBlocked(to) ||
+				!isPatternStep(s, pid, from, m.Offset()) {
+				continue
+			}
+			moves[pid-1] = &simultaneousMove{
+				piece: p,
+				from:  from,
+				to:    to,
+			}
+		}
+	}
+	return moves
+}
+
+// handleHits deals the damage of all the hits at once, levels up the Pieces
+// which survived for every Piece they helped destroy, and removes the destroyed
+// Pieces from the State.
+func handleHits(s *State, hits []hit) {
+	if len(hits) == 0 {
+		return
+	}
+	// Damage can't be undone, so no earlier position can repeat.
+	s.positions = nil
+	damage := make(map[PieceID]int)
+	for _, h := range hits {
+		a, _ := s.board.Piece(h.attacker)
+		t, _ := s.board.Piece(h.target)
+		damage[h.target] += a.Damage()
+		s.emit(Event{
+			Kind:   AttackedEvent,
+			Piece:  a.ID(),
+			Target: t.ID(),
+			From:   s.CellForPiece(a),
+			To:     s.CellForPiece(t),
+			Life:   t.Life() - damage[h.target],
+			Damage: a.Damage(),
+		})
+	}
+	for pid, d := range damage {
+		p, _ := s.board.Piece(pid)
+		s.setPiece(pid, p.withStats(p.Life()-d, p.Damage()))
+	}
+	for _, h := range hits {
+		a, _ := s.board.Piece(h.attacker)
+		t, _ := s.board.Piece(h.target)
+		if a.Life() > 0 && t.Life() <= 0 {
+			s.levelUp(a)
+		}
+	}
+	for _, id := range s.Rules().PlayerIDs() {
+		for _, p := range s.board.PlayerPieces(id) {
+			if p.Life() > 0 {
+				continue
+			}
+			s.emitDestroyed(p)
+			s.destroyPiece(p)
+			s.piecesAlive[id]--
+			if s.piecesAlive[id] == 0 {
+				s.eliminated = append(s.eliminated, id)
+			}
+		}
+	}
+}
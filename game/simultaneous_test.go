@@ -0,0 +1,312 @@
+package game_test
+
+import (
+	"testing"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// TestNextStateWithPlays tests that game.Plays made at the same time are
+// resolved with the rules for following, attacking, meeting head-on,
+// colliding, and destroying each other.
+func TestNextStateWithPlays(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(0, 2, 2, 1, 1, 1).WithBoardSize(5, 5)
+	r = r.WithPlayMode(game.SimultaneousPlays)
+	type move struct {
+		Piece     game.PieceID
+		Direction game.Direction
+	}
+	type want struct {
+		Cell         game.Cell
+		Life, Damage int
+	}
+	cases := []struct {
+		Name   string
+		Pieces map[game.Cell]game.Piece
+		Plays  map[game.PlayerID][]move
+		Want   map[game.PieceID]want
+	}{
+		{
+			Name: "follow",
+			Pieces: map[game.Cell]game.Piece{
+				game.NewCell(0, 0): game.NewPiece(1, 2, 1),
+				game.NewCell(0, 1): game.NewPiece(2, 2, 1),
+				game.NewCell(4, 4): game.NewPiece(3, 2, 1),
+			},
+			Plays: map[game.PlayerID][]move{
+				game.Player1: {{1, game.East}, {2, game.East}},
+			},
+			Want: map[game.PieceID]want{
+				1: {game.NewCell(0, 1), 2, 1},
+				2: {game.NewCell(0, 2), 2, 1},
+				3: {game.NewCell(4, 4), 2, 1},
+			},
+		},
+		{
+			Name: "attack",
+			Pieces: map[game.Cell]game.Piece{
+				game.NewCell(2, 2): game.NewPiece(1, 2, 1),
+				game.NewCell(3, 2): game.NewPiece(3, 2, 1),
+			},
+			Plays: map[game.PlayerID][]move{
+				game.Player1: {{1, game.South}},
+			},
+			Want: map[game.PieceID]want{
+				1: {game.NewCell(2, 2), 2, 1},
+				3: {game.NewCell(3, 2), 1, 1},
+			},
+		},
+		{
+			Name: "flee",
+			Pieces: map[game.Cell]game.Piece{
+				game.NewCell(2, 2): game.NewPiece(1, 2, 1),
+				game.NewCell(2, 3): game.NewPiece(3, 2, 1),
+			},
+			Plays: map[game.PlayerID][]move{
+				game.Player1: {{1, game.East}},
+				game.Player2: {{3, game.East}},
+			},
+			Want: map[game.PieceID]want{
+				1: {game.NewCell(2, 3), 2, 1},
+				3: {game.NewCell(2, 4), 2, 1},
+			},
+		},
+		{
+			Name: "head-on",
+			Pieces: map[game.Cell]game.Piece{
+				game.NewCell(2, 2): game.NewPiece(1, 2, 1),
+				game.NewCell(2, 3): game.NewPiece(3, 2, 1),
+			},
+			Plays: map[game.PlayerID][]move{
+				game.Player1: {{1, game.East}},
+				game.Player2: {{3, game.West}},
+			},
+			Want: map[game.PieceID]want{
+				1: {game.NewCell(2, 2), 1, 1},
+				3: {game.NewCell(2, 3), 1, 1},
+			},
+		},
+		{
+			Name: "collision",
+			Pieces: map[game.Cell]game.Piece{
+				game.NewCell(2, 1): game.NewPiece(1, 2, 1),
+				game.NewCell(2, 3): game.NewPiece(3, 2, 1),
+			},
+			Plays: map[game.PlayerID][]move{
+				game.Player1: {{1, game.East}},
+				game.Player2: {{3, game.West}},
+			},
+			Want: map[game.PieceID]want{
+				1: {game.NewCell(2, 1), 1, 1},
+				3: {game.NewCell(2, 3), 1, 1},
+			},
+		},
+		{
+			Name: "attack head-on",
+			Pieces: map[game.Cell]game.Piece{
+				game.NewCell(2, 2): game.NewPiece(1, 2, 1),
+				game.NewCell(1, 3): game.NewPiece(2, 2, 1),
+				game.NewCell(2, 3): game.NewPiece(3, 2, 1),
+				game.NewCell(4, 4): game.NewPiece(4, 2, 1),
+			},
+			Plays: map[game.PlayerID][]move{
+				game.Player1: {{1, game.East}, {2, game.South}},
+				game.Player2: {{3, game.West}},
+			},
+			Want: map[game.PieceID]want{
+				1: {game.NewCell(2, 2), 2, 2},
+				2: {game.NewCell(1, 3), 3, 2},
+				3: {game.NoCell, 0, 0},
+			},
+		},
+		{
+			Name: "collide into blocked",
+			Pieces: map[game.Cell]game.Piece{
+				game.NewCell(1, 2): game.NewPiece(1, 2, 1),
+				game.NewCell(3, 2): game.NewPiece(2, 2, 1),
+				game.NewCell(2, 2): game.NewPiece(3, 2, 1),
+				game.NewCell(2, 3): game.NewPiece(4, 2, 1),
+			},
+			Plays: map[game.PlayerID][]move{
+				game.Player1: {
+					{1, game.South}, {2, game.North},
+				},
+				game.Player2: {{3, game.East}},
+			},
+			Want: map[game.PieceID]want{
+				1: {game.NewCell(1, 2), 3, 2},
+				2: {game.NewCell(3, 2), 3, 2},
+				3: {game.NoCell, 0, 0},
+				4: {game.NewCell(2, 3), 2, 1},
+			},
+		},
+		{
+			Name: "swap allies",
+			Pieces: map[game.Cell]game.Piece{
+				game.NewCell(0, 0): game.NewPiece(1, 2, 1),
+				game.NewCell(0, 1): game.NewPiece(2, 2, 1),
+				game.NewCell(4, 4): game.NewPiece(3, 2, 1),
+			},
+			Plays: map[game.PlayerID][]move{
+				game.Player1: {{1, game.East}, {2, game.West}},
+			},
+			Want: map[game.PieceID]want{
+				1: {game.NewCell(0, 1), 2, 1},
+				2: {game.NewCell(0, 0), 2, 1},
+			},
+		},
+		{
+			Name: "blocked chain",
+			Pieces: map[game.Cell]game.Piece{
+				game.NewCell(0, 0): game.NewPiece(1, 2, 1),
+				game.NewCell(0, 1): game.NewPiece(2, 2, 1),
+				game.NewCell(0, 2): game.NewPiece(3, 2, 1),
+			},
+			Plays: map[game.PlayerID][]move{
+				game.Player1: {{1, game.East}, {2, game.East}},
+			},
+			Want: map[game.PieceID]want{
+				1: {game.NewCell(0, 0), 2, 1},
+				2: {game.NewCell(0, 1), 2, 1},
+				3: {game.NewCell(0, 2), 1, 1},
+			},
+		},
+		{
+			Name: "gang attack",
+			Pieces: map[game.Cell]game.Piece{
+				game.NewCell(1, 2): game.NewPiece(1, 2, 1),
+				game.NewCell(2, 1): game.NewPiece(2, 2, 1),
+				game.NewCell(2, 2): game.NewPiece(3, 2, 1),
+				game.NewCell(4, 4): game.NewPiece(4, 2, 1),
+			},
+			Plays: map[game.PlayerID][]move{
+				game.Player1: {{1, game.South}, {2, game.East}},
+			},
+			Want: map[game.PieceID]want{
+				1: {game.NewCell(1, 2), 3, 2},
+				2: {game.NewCell(2, 1), 3, 2},
+				3: {game.NoCell, 0, 0},
+			},
+		},
+		{
+			Name: "mutual kill",
+			Pieces: map[game.Cell]game.Piece{
+				game.NewCell(2, 2): game.NewPiece(1, 1, 1),
+				game.NewCell(2, 3): game.NewPiece(3, 1, 1),
+				game.NewCell(0, 0): game.NewPiece(2, 2, 1),
+				game.NewCell(4, 4): game.NewPiece(4, 2, 1),
+			},
+			Plays: map[game.PlayerID][]move{
+				game.Player1: {{1, game.East}},
+				game.Player2: {{3, game.West}},
+			},
+			Want: map[game.PieceID]want{
+				1: {game.NoCell, 0, 0},
+				3: {game.NoCell, 0, 0},
+			},
+		},
+	}
+	for _, test := range cases {
+		s := game.NewStateFromInfo(
+			r, game.Player1,
+			normal1{}, normal2{},
+			test.Pieces,
+		)
+		plays := make(map[game.PlayerID]game.Play)
+		for id, ms := range test.Plays {
+			for _, m := range ms {
+				p := game.NewPiece(m.Piece, 0, 0)
+				move := game.NewMove(p, m.Direction)
+				plays[id] = append(plays[id], move)
+			}
+		}
+		s = game.NextStateWithPlays(s, plays)
+		for pid, w := range test.Want {
+			p := game.NewPiece(pid, w.Life, w.Damage)
+			if c := s.CellForPiece(p); c != w.Cell {
+				t.Errorf(
+					"%s: s.CellForPiece(%v) = %v, want %v",
+					test.Name, p, c, w.Cell,
+				)
+				continue
+			}
+			if w.Cell == game.NoCell {
+				continue
+			}
+			if got := s.PieceForCell(w.Cell); got != p {
+				t.Errorf(
+					"%s: s.PieceForCell(%v) = %v, want %v",
+					test.Name, w.Cell, got, p,
+				)
+			}
+		}
+	}
+}
+
+// TestNextStateWithPlaysDraw tests that a game is a draw when the last
+// game.Pieces of every game.Player destroy each other.
+func TestNextStateWithPlaysDraw(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(0, 1, 1, 1, 1, 1).WithBoardSize(3, 3)
+	r = r.WithPlayMode(game.SimultaneousPlays)
+	s := game.NewState(r, normal1{}, normal2{})
+	p1 := s.Player1Pieces()[0]
+	p2 := s.Player2Pieces()[0]
+	s = game.NextStateWithPlays(s, map[game.PlayerID]game.Play{
+		game.Player1: {game.NewMove(p1, game.South)},
+		game.Player2: {game.NewMove(p2, game.North)},
+	})
+	if !s.IsDraw() || s.Winner() != game.NoPlayer {
+		t.Errorf(
+			"s.IsDraw() = %v, s.Winner() = %v, want %v, %v",
+			s.IsDraw(), s.Winner(), true, game.NoPlayer,
+		)
+	}
+}
+
+// TestNextStateSimultaneous tests that game.NextState asks every game.Player
+// for a game.Play from their own view of the game.State in games with
+// simultaneous game.Plays.
+func TestNextStateSimultaneous(t *testing.T) {
+	t.Parallel()
+	r := game.StandardRules.WithPlayMode(game.SimultaneousPlays)
+	s := game.NewState(r, advancing{}, advancing{})
+	s = game.NextState(s)
+	for _, p := range s.Player1Pieces() {
+		if c := s.CellForPiece(p); c.Row() != 1 {
+			t.Errorf("s.CellForPiece(%v) = %v, want row 1", p, c)
+		}
+	}
+	for _, p := range s.Player2Pieces() {
+		if c := s.CellForPiece(p); c.Row() != r.BoardHeight()-2 {
+			t.Errorf(
+				"s.CellForPiece(%v) = %v, want row %d",
+				p, c, r.BoardHeight()-2,
+			)
+		}
+	}
+	if s.CurrentPlayer() != game.Player1 || s.Turn() != 1 {
+		t.Errorf(
+			"s.CurrentPlayer() = %v, s.Turn() = %d, want %v, %d",
+			s.CurrentPlayer(), s.Turn(), game.Player1, 1,
+		)
+	}
+}
+
+// advancing game.Player moves all its game.Pieces towards the other side of
+// the board.
+type advancing struct{}
+
+// Play moving every game.Piece forward.
+func (p advancing) Play(s *game.State) game.Play {
+	d := game.South
+	if s.CurrentPlayer() == game.Player2 {
+		d = game.North
+	}
+	var play game.Play
+	for _, piece := range s.CurrentPlayerPieces() {
+		play = append(play, game.NewMove(piece, d))
+	}
+	return play
+}
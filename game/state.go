@@ -1,14 +1,35 @@
 package game
 
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
 // State encapsulates all of the game data in an immutable fashion.
+//
+// Pieces and the Cells they're in are packed into a board so the copy of the
+// State each Play makes is cheap.
 type State struct {
-	player1PiecesAlive, player2PiecesAlive int
-	currentPlayer                          PlayerID
-	rules                                  Rules
-	players                                []Player
-	pieces                                 pieceIDMap
-	piecesToCells                          pieceMap
-	cellsToPieceIDs                        cellMap
+	piecesAlive   [MaxPlayerCount + 1]int
+	territory     [MaxPlayerCount + 1]int
+	eliminated    []PlayerID
+	turn          int
+	hash          uint64
+	positions     *position
+	history       bool
+	deploying     bool
+	previous      *State
+	lastPlay      Play
+	events        []Event
+	timedOut      []PlayerID
+	forfeited     PlayerID
+	currentPlayer PlayerID
+	viewer        PlayerID
+	rules         Rules
+	players       []Player
+	board         board
 }
 
 // NewState creates an initial game State where the game is being played by
@@ -16,93 +37,281 @@ type State struct {
 //
 // Player one is set to move first.
 func NewState(r Rules, p1, p2 Player) *State {
-	pieces := newPieceIDMap(r.PieceCount())
-	cs := newPieceMap(r.PieceCount())
-	ps := newCellMap(r.BoardSize())
-	for i := 0; i < r.PieceCount(); i++ {
-		p1id := PieceID(i + 1)
-		p2id := PieceID(i + r.PieceCount() + 1)
-		p1 := NewPiece(p1id, r.Life(), r.Damage())
-		p2 := NewPiece(p2id, r.Life(), r.Damage())
-		c1 := NewCell(0, i*2+1)
-		c2 := NewCell(r.BoardSize()-1, i*2+1)
-		pieces.Set(p1id, p1)
-		pieces.Set(p2id, p2)
-		cs.Set(p1, c1)
-		cs.Set(p2, c2)
-		ps.Set(c1, p1id)
-		ps.Set(c2, p2id)
+	return NewStateWithPlayers(r, []Player{p1, p2})
+}
+
+// NewStateWithPlayers creates an initial game State where the game is being
+// played by the Players in order with the given Rules.
+//
+// There should be a Player for each of the Rules' PlayerIDs. Player one is set
+// to move first. If the Rules have HomeZones, the game starts in its
+// deployment phase with Player one deploying first.
+func NewStateWithPlayers(r Rules, ps []Player) *State {
+	pieces := make(map[Cell]Piece)
+	for _, id := range r.PlayerIDs() {
+		for i := 0; i < r.PieceCount(); i++ {
+			pid := PieceID((int(id)-1)*r.PieceCount() + i + 1)
+			p := r.newPiece(pid, r.pieceClass(id, i))
+			pieces[startCell(r, id, i)] = p
+		}
 	}
-	return &State{
-		player1PiecesAlive: r.PieceCount(),
-		player2PiecesAlive: r.PieceCount(),
-		currentPlayer:      Player1,
-		rules:              r,
-		piecesToCells:      cs,
-		cellsToPieceIDs:    ps,
-		players:            []Player{Player1: p1, Player2: p2},
-		pieces:             pieces,
+	s := NewStateFromInfoWithPlayers(r, Player1, ps, pieces)
+	s.deploying = r.HomeZones() != nil
+	return s
+}
+
+// startCell returns the Cell the Piece with the index belonging to the Player
+// with the PlayerID starts in.
+//
+// Pieces start in the Cells the Rules' Layout assigns them. Otherwise, Pieces
+// are spread evenly along the Player's side of the board. Player one starts on
+// the top, Player two on the bottom, Player three on the left, and Player four
+// on the right. Rows or columns further in are used once a side is full.
+func startCell(r Rules, id PlayerID, i int) Cell {
+	if cs := r.Layout().Cells(id); i < len(cs) {
+		return cs[i]
+	}
+	w, h := r.BoardWidth(), r.BoardHeight()
+	side := w
+	if id == Player3 || id == Player4 {
+		side = h
+	}
+	depth := i / side
+	onSide := r.PieceCount() - depth*side
+	if onSide > side {
+		onSide = side
+	}
+	along := ((2*(i%side) + 1) * side) / (2 * onSide)
+	switch id {
+	case Player2:
+		return NewCell(h-1-depth, along)
+	case Player3:
+		return NewCell(along, depth)
+	case Player4:
+		return NewCell(along, w-1-depth)
+	default:
+		return NewCell(depth, along)
 	}
 }
 
 // NewStateFromInfo creates a State using info from a game already in progress.
+//
+// The State starts at turn zero with no record of earlier positions. WithTurn
+// sets the turn.
 func NewStateFromInfo(
 	rules Rules,
 	currentPlayer PlayerID,
 	p1 Player, p2 Player,
 	pieces map[Cell]Piece,
 ) *State {
-	ps := newPieceIDMap(rules.PieceCount())
-	cs := newPieceMap(rules.PieceCount())
-	cm := newCellMap(rules.BoardSize())
-	var p1Alive, p2Alive int
+	return NewStateFromInfoWithPlayers(
+		rules,
+		currentPlayer,
+		[]Player{p1, p2},
+		pieces,
+	)
+}
+
+// NewStateFromInfoWithPlayers is NewStateFromInfo for games played by the
+// Players in order.
+//
+// Players without any Pieces are eliminated in the order of their PlayerIDs.
+func NewStateFromInfoWithPlayers(
+	rules Rules,
+	currentPlayer PlayerID,
+	players []Player,
+	pieces map[Cell]Piece,
+) *State {
+	s := &State{
+		currentPlayer: currentPlayer,
+		rules:         rules,
+		players:       append([]Player{NoPlayer: nil}, players...),
+		board: newBoard(
+			rules.BoardWidth(), rules.BoardHeight(),
+			rules.PieceCount(), rules.PlayerCount(),
+			rules.TerritoryControl() > 0,
+		),
+	}
 	for c, p := range pieces {
-		if p.ID() != NoPieceID {
-			pid := int(p.ID())
-			if pid <= rules.PieceCount() {
-				p1Alive++
-			}
-			if pid > rules.PieceCount() {
-				p2Alive++
-			}
+		if id := s.playerForPieceID(p.ID()); id != NoPlayer {
+			s.piecesAlive[id]++
 		}
-		ps.Set(p.ID(), p)
-		cs.Set(p, c)
-		cm.Set(c, p.ID())
+		s.board.SetPiece(p.ID(), p)
+		s.board.SetCell(p.ID(), c)
+		s.board.SetPieceID(c, p.ID())
+		s.claim(c, s.playerForPieceID(p.ID()))
 	}
-	return &State{
-		player1PiecesAlive: p1Alive,
-		player2PiecesAlive: p2Alive,
-		currentPlayer:      currentPlayer,
-		rules:              rules,
-		players:            []Player{Player1: p1, Player2: p2},
-		pieces:             ps,
-		piecesToCells:      cs,
-		cellsToPieceIDs:    cm,
+	for _, id := range rules.PlayerIDs() {
+		if s.piecesAlive[id] == 0 {
+			s.eliminated = append(s.eliminated, id)
+		}
 	}
+	s.hash = computeHash(s)
+	s.recordPosition()
+	return s
 }
 
 // NextState returns the next State with the Play the current Player chooses.
+//
+// The current Player has the Rules' TimerDuration to choose the Play. If they
+// take longer, they make an empty Play or forfeit the game depending on the
+// Rules' TimeoutPolicy and are the next State's TimedOut Player. Players who
+// forfeit are eliminated and their Pieces are removed from the board.
+//
+// In games with SimultaneousPlays, every Player who hasn't been eliminated
+// chooses a Play at the same time from their own view of the State given by
+// AsPlayer and the Plays are made with NextStateWithPlays.
+//
+// In games with fog of war, Players choose from their PlayerView instead.
+//
+// During the deployment phase, the current Player deploys their Pieces instead
+// as described for Deployer.
 func NextState(s *State) *State {
-	return NextStateWithPlay(s, s.players[s.CurrentPlayer()].Play(s))
+	n, _ := NextStateContext(context.Background(), s)
+	return n
+}
+
+// NextStateContext is NextState where the current Player is told to stop
+// choosing their Play once the Context is done.
+//
+// The State is returned unchanged along with the Context's error if the Context
+// is done before the Player chooses. Players who fail to choose a Play for any
+// other reason make an empty Play.
+func NextStateContext(ctx context.Context, s *State) (*State, error) {
+	if s.IsDeploying() {
+		return nextDeployment(ctx, s)
+	}
+	ids := []PlayerID{s.CurrentPlayer()}
+	if s.Rules().PlayMode() == SimultaneousPlays {
+		ids = s.alivePlayers()
+	}
+	turn := ctx
+	if td := s.Rules().TimerDuration(); td > 0 {
+		var cancel context.CancelFunc
+		turn, cancel = context.WithTimeout(ctx, td)
+		defer cancel()
+	}
+	plays := make([]Play, len(ids))
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id PlayerID) {
+			defer wg.Done()
+			view := s.AsPlayer(id).PlayerView(id)
+			p := AsContextPlayer(s.Player(id))
+			plays[i], errs[i] = p.PlayContext(turn, view)
+		}(i, id)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return s, err
+	}
+	byPlayer := make(map[PlayerID]Play, len(ids))
+	var timedOut []PlayerID
+	for i, id := range ids {
+		late := errors.Is(errs[i], context.DeadlineExceeded)
+		if late && turn.Err() != nil {
+			timedOut = append(timedOut, id)
+			continue
+		}
+		byPlayer[id] = plays[i]
+	}
+	n := NextStateWithPlays(s, byPlayer)
+	for _, id := range timedOut {
+		if s.Rules().TimeoutPolicy() == ForfeitOnTimeout {
+			n.forfeit(id)
+		}
+	}
+	n.timedOut = timedOut
+	return n, nil
 }
 
 // NextStateWithPlay returns the next State ignoring what the current Player
 // would've done and instead uses the moves in the given Play.
+//
+// Moves are made in order. Moves of a Piece beyond the number its Class allows
+// in a Play, Moves of other Players' Pieces, and Moves which the Rules'
+// Pattern doesn't allow, slide through a blocked or occupied Cell, leave the
+// board, enter blocked Cells, or enter Cells held by the current Player's
+// Pieces are ignored. Pieces always have their life and damage from the State
+// no matter what the Moves give them. NextStateWithCheckedPlay reports illegal
+// Moves instead.
+//
+// The State is returned unchanged if the game is already over. In games with
+// SimultaneousPlays, every other Player makes an empty Play. During the
+// deployment phase, the Play is ignored and the current Player deploys their
+// Pieces as if they weren't a Deployer.
 func NextStateWithPlay(s *State, p Play) *State {
+	if s.IsDeploying() {
+		return deploy(s, defaultDeployment(s, s.CurrentPlayer()))
+	}
+	if s.Rules().PlayMode() == SimultaneousPlays {
+		ps := map[PlayerID]Play{s.CurrentPlayer(): p}
+		return NextStateWithPlays(s, ps)
+	}
+	previous := s
 	s = clone(s)
-	if s.Winner() != NoPlayer {
+	if s.IsOver() {
 		return s
 	}
-	set := make([]bool, s.Rules().PieceCount()*2)
+	if s.history {
+		s.previous = previous
+		// The Play is copied since callers like LegalPlaySeq reuse it.
+		s.lastPlay = slices.Clone(p)
+	}
+	makePlay(s, p)
+	return s
+}
+
+// makePlay for the current Player by changing the State itself as described
+// for NextStateWithPlay.
+func makePlay(s *State, p Play) {
+	s.timedOut = nil
+	// Every Move emits an Event.
+	s.events = make([]Event, 0, len(p))
+	moved := make([]int, s.Rules().PieceCount()*s.Rules().PlayerCount())
+	var hits []hit
 	for _, m := range p {
-		if ok := set[m.Piece().ID()-1]; !ok {
-			applyMove(s, m)
-			set[m.Piece().ID()-1] = true
+		pid := m.Piece().ID()
+		if s.playerForPieceID(pid) != s.CurrentPlayer() {
+			continue
+		}
+		if moved[pid-1] < s.allowance(pid) {
+			if t := applyMove(s, m); t != NoPieceID {
+				h := hit{attacker: pid, target: t}
+				hits = append(hits, h)
+			}
+			moved[pid-1]++
 		}
 	}
-	handleDestroyed(s, p)
-	s.currentPlayer = s.NextPlayer()
+	handleDestroyed(s, hits)
+	s.setCurrentPlayer(s.NextPlayer())
+	s.turn++
+	s.recordPosition()
+}
+
+// WithTurn returns a copy of the State which is at the given turn.
+//
+// This is meant for restoring games already in progress, such as ones created
+// with NewStateFromInfo.
+func (s *State) WithTurn(n int) *State {
+	s = clone(s)
+	s.turn = n
+	return s
+}
+
+// AsPlayer returns a copy of the State where the Player with the PlayerID is
+// the current Player.
+//
+// In games with SimultaneousPlays, this is the State each Player chooses their
+// Play from.
+func (s *State) AsPlayer(id PlayerID) *State {
+	if s.CurrentPlayer() == id {
+		return s
+	}
+	s = clone(s)
+	s.setCurrentPlayer(id)
 	return s
 }
 
@@ -114,50 +323,63 @@ func (s *State) CurrentPlayer() PlayerID {
 
 // NextPlayer returns the PlayerID of the Player who will play in the next
 // State.
+//
+// This is the first Player after the current Player in order who hasn't been
+// eliminated.
 func (s *State) NextPlayer() PlayerID {
-	switch s.CurrentPlayer() {
-	case Player1:
-		return Player2
-	case Player2:
-		return Player1
-	default:
+	cur := int(s.CurrentPlayer())
+	n := s.Rules().PlayerCount()
+	if cur < 1 || cur > n {
 		return NoPlayer
 	}
+	for i := 1; i <= n; i++ {
+		id := PlayerID((cur-1+i)%n + 1)
+		if s.piecesAlive[id] > 0 {
+			return id
+		}
+	}
+	return NoPlayer
 }
 
 // CurrentPlayerPieces returns all the Pieces which belong to the Player who is
 // playing in this State.
 func (s *State) CurrentPlayerPieces() []Piece {
-	return removePiece(s.currentPlayerPieces(), NoPiece)
+	return s.currentPlayerPieces()
 }
 
 // NextPlayerPieces returns all the Pieces which belong to the Player who will
 // play in the next State.
 func (s *State) NextPlayerPieces() []Piece {
-	return removePiece(s.nextPlayerPieces(), NoPiece)
+	return s.nextPlayerPieces()
 }
 
 // Player1Pieces returns all the Pieces which belong to the Player with PlayerID
 // Player1.
 func (s *State) Player1Pieces() []Piece {
-	return removePiece(s.player1Pieces(), NoPiece)
+	return s.player1Pieces()
 }
 
 // Player2Pieces returns all the Pieces which belong to the Player with PlayerID
 // Player2.
 func (s *State) Player2Pieces() []Piece {
-	return removePiece(s.player2Pieces(), NoPiece)
+	return s.player2Pieces()
+}
+
+// PlayerPieces returns all the Pieces which belong to the Player with the
+// PlayerID.
+func (s *State) PlayerPieces(id PlayerID) []Piece {
+	return s.board.PlayerPieces(id)
 }
 
-// Pieces returns the Pieces for Player one and Player two.
+// Pieces returns the Pieces for every Player.
 func (s *State) Pieces() []Piece {
-	return append(s.Player1Pieces(), s.Player2Pieces()...)
+	return s.board.Pieces()
 }
 
 // CellForPiece returns the Cell the Piece is in or NoCell if the Piece is not
 // in a Cell.
 func (s *State) CellForPiece(p Piece) Cell {
-	if c, ok := s.piecesToCells.Get(p); ok {
+	if c, ok := s.board.Cell(p.ID()); ok {
 		return c
 	}
 	return NoCell
@@ -166,8 +388,8 @@ func (s *State) CellForPiece(p Piece) Cell {
 // PieceForCell returns the Piece in a Cell of NoPiece if the Cell is empty at
 // the current State.
 func (s *State) PieceForCell(c Cell) Piece {
-	if pid, ok := s.cellsToPieceIDs.Get(c); ok {
-		if p, ok := s.pieces.Get(pid); ok {
+	if pid, ok := s.board.PieceID(c); ok {
+		if p, ok := s.board.Piece(pid); ok {
 			return p
 		}
 		return NoPiece
@@ -185,23 +407,30 @@ func (s *State) PlayerForPiece(p Piece) PlayerID {
 func (s *State) playerForPieceID(pid PieceID) PlayerID {
 	id := int(pid)
 	pc := s.Rules().PieceCount()
-	if id > 0 && id <= pc {
-		return Player1
-	}
-	if id > pc && id <= 2*pc {
-		return Player2
+	if id <= 0 || id > pc*s.Rules().PlayerCount() {
+		return NoPlayer
 	}
-	return NoPlayer
+	return PlayerID((id-1)/pc + 1)
 }
 
 // Player1 of the game.
 func (s *State) Player1() Player {
-	return s.players[Player1]
+	return s.Player(Player1)
 }
 
 // Player2 of the game.
 func (s *State) Player2() Player {
-	return s.players[Player2]
+	return s.Player(Player2)
+}
+
+// Player with the PlayerID.
+//
+// nil is returned if no Player has the PlayerID.
+func (s *State) Player(id PlayerID) Player {
+	if id <= NoPlayer || int(id) >= len(s.players) {
+		return nil
+	}
+	return s.players[id]
 }
 
 // Rules which control the game.
@@ -209,33 +438,87 @@ func (s *State) Rules() Rules {
 	return s.rules
 }
 
-// Winner of the game at the State if there is one.
+// Turn is the number of Plays made in the game to reach the State.
+func (s *State) Turn() int {
+	return s.turn
+}
+
+// TimedOut returns the PlayerID of the Player who ran out of time choosing the
+// Play which led to the State.
 //
-// NoPlayer is returned if there is no winner.
-func (s *State) Winner() PlayerID {
-	if s.player1PiecesAlive == 0 {
-		return Player2
-	}
-	if s.player2PiecesAlive == 0 {
-		return Player1
+// NoPlayer is returned if no Player ran out of time. TimedOutPlayers returns
+// all of them in games with SimultaneousPlays.
+func (s *State) TimedOut() PlayerID {
+	if len(s.timedOut) == 0 {
+		return NoPlayer
 	}
-	return NoPlayer
+	return s.timedOut[0]
+}
+
+// TimedOutPlayers returns the PlayerIDs of all the Players who ran out of time
+// choosing the Plays which led to the State in order.
+func (s *State) TimedOutPlayers() []PlayerID {
+	return append([]PlayerID{}, s.timedOut...)
+}
+
+// Forfeited returns the PlayerID of the last Player who forfeited the game by
+// running out of time.
+//
+// NoPlayer is returned if no Player forfeited.
+func (s *State) Forfeited() PlayerID {
+	return s.forfeited
 }
 
-// handleMoves applies all the Moves in the Play to the State.
+// Eliminated returns the PlayerIDs of the Players who have lost all their
+// Pieces or forfeited in the order they were eliminated.
 //
-// Destroyed Pieces aren't removed from the State and levels aren't given to the
-// Piecs that destroyed those Pieces.
-func handleMoves(s *State, ms Play) {
-	for _, m := range ms {
-		if !IsLegalMove(s, m) {
+// Players eliminated by the same Play are ordered by PlayerID.
+func (s *State) Eliminated() []PlayerID {
+	return append([]PlayerID{}, s.eliminated...)
+}
+
+// Winner of the game at the State if there is one.
+//
+// The winner is the last Player who hasn't been eliminated or, in games with
+// territory control, the Player who has won by territory as described for
+// WithTerritoryControl. NoPlayer is returned if there is no winner.
+func (s *State) Winner() PlayerID {
+	winner := NoPlayer
+	for _, id := range s.Rules().PlayerIDs() {
+		if s.piecesAlive[id] == 0 {
 			continue
 		}
-		s.piecesToCells.Set(m.Piece(), nextCell(
-			s.CellForPiece(m.Piece()),
-			m.Direction(),
-		))
+		if winner != NoPlayer {
+			return s.territoryWinner()
+		}
+		winner = id
 	}
+	return winner
+}
+
+// IsDraw returns true iff the game ended at the State without a winner.
+//
+// A game is drawn once the Rules' MaxTurns have been played, the same position
+// has occurred RepetitionLimit times, or every remaining Player is eliminated
+// at once. Positions are the same if they have the same Hash. In games with
+// territory control, MaxTurns only end in a draw if the most Cells are tied.
+func (s *State) IsDraw() bool {
+	if s.Winner() != NoPlayer {
+		return false
+	}
+	if len(s.eliminated) == s.Rules().PlayerCount() {
+		return true
+	}
+	if mt := s.Rules().MaxTurns(); mt > 0 && s.Turn() >= mt {
+		return true
+	}
+	rl := s.Rules().RepetitionLimit()
+	return rl > 0 && s.positions != nil && s.positions.count >= rl
+}
+
+// IsOver returns true iff the game has a winner or is a draw at the State.
+func (s *State) IsOver() bool {
+	return s.Winner() != NoPlayer || s.IsDraw()
 }
 
 // nextCells is a hardcoded list of direction Cells for use in determining
@@ -254,119 +537,234 @@ var nextCells = []Cell{
 
 // nextCell obtained by moving a cell in the Direction from the original Cell.
 func nextCell(c Cell, d Direction) Cell {
-	dd := nextCells[d]
-	return NewCell(c.Row()+dd.Row(), c.Column()+dd.Column())
+	return offsetCell(c, d.Offset())
 }
 
 // clone the mutable parts of a State into a new one.
 func clone(s *State) *State {
+	// Capping the capacity makes appending to either State's eliminated
+	// Players copy them first.
+	eliminated := s.eliminated[:len(s.eliminated):len(s.eliminated)]
 	return &State{
-		player1PiecesAlive: s.player1PiecesAlive,
-		player2PiecesAlive: s.player2PiecesAlive,
-		players:            s.players,
-		currentPlayer:      s.CurrentPlayer(),
-		rules:              s.Rules(),
-		pieces:             s.pieces.clone(),
-		piecesToCells:      s.piecesToCells.clone(),
-		cellsToPieceIDs:    s.cellsToPieceIDs.clone(),
+		piecesAlive:   s.piecesAlive,
+		territory:     s.territory,
+		eliminated:    eliminated,
+		turn:          s.turn,
+		hash:          s.hash,
+		positions:     s.positions,
+		history:       s.history,
+		deploying:     s.deploying,
+		previous:      s.previous,
+		lastPlay:      s.lastPlay,
+		events:        s.events,
+		timedOut:      s.timedOut,
+		forfeited:     s.forfeited,
+		players:       s.players,
+		currentPlayer: s.CurrentPlayer(),
+		viewer:        s.viewer,
+		rules:         s.Rules(),
+		board:         s.board.clone(),
 	}
 }
 
-// player1Pieces returns a non-copied list of Player one's Pieces.
+// player1Pieces returns a list of Player one's Pieces.
 func (s *State) player1Pieces() []Piece {
-	return s.pieces.Player1Pieces()
+	return s.board.PlayerPieces(Player1)
 }
 
-// player2Pieces returns a non-copied list of Player two's Pieces.
+// player2Pieces returns a list of Player two's Pieces.
 func (s *State) player2Pieces() []Piece {
-	return s.pieces.Player2Pieces()
+	return s.board.PlayerPieces(Player2)
 }
 
-// currentPlayerPieces returns a non-copied list of the current Player's Pieces.
+// currentPlayerPieces returns a list of the current Player's Pieces.
 func (s *State) currentPlayerPieces() []Piece {
-	switch s.CurrentPlayer() {
-	case Player1:
-		return s.player1Pieces()
-	case Player2:
-		return s.player2Pieces()
-	default:
-		return nil
-	}
+	return s.board.PlayerPieces(s.CurrentPlayer())
 }
 
-// nextPlayerPieces returns a non-copied list of the next Player's Pieces.
+// nextPlayerPieces returns a list of the next Player's Pieces.
 func (s *State) nextPlayerPieces() []Piece {
-	switch s.CurrentPlayer() {
-	case Player1:
-		return s.player2Pieces()
-	case Player2:
-		return s.player1Pieces()
-	default:
-		return nil
-	}
+	return s.board.PlayerPieces(s.NextPlayer())
 }
 
 // handleDestroyed removes all the destroyed Pieces from the State and levels up
 // the Pieces that destroyed them according to the State's Rules.
-func handleDestroyed(s *State, ms Play) {
-	li := s.Rules().LifeIncrease()
-	di := s.Rules().DamageIncrease()
-	for _, p := range s.nextPlayerPieces() {
-		if p != NoPiece && p.Life() <= 0 {
-			for _, m := range ms {
-				if nextCell(
-					s.CellForPiece(m.Piece()),
-					m.Direction(),
-				) != s.CellForPiece(p) {
+//
+// Pieces level up once for every Piece they hit which was destroyed. Only
+// Pieces which were hit can be destroyed.
+func handleDestroyed(s *State, hits []hit) {
+	if len(hits) == 0 {
+		return
+	}
+	for _, id := range s.Rules().PlayerIDs() {
+		if id != s.CurrentPlayer() {
+			handleDestroyedPieces(s, hits, id)
+		}
+	}
+}
+
+// handleDestroyedPieces handles the destroyed Pieces of the Player with the
+// PlayerID and eliminates the Player if they have no Pieces left.
+func handleDestroyedPieces(s *State, hits []hit, id PlayerID) {
+	for _, p := range s.board.PlayerPieces(id) {
+		if p.Life() <= 0 {
+			s.emitDestroyed(p)
+			leveled := make(map[PieceID]bool)
+			for _, h := range hits {
+				if h.target != p.ID() || leveled[h.attacker] {
 					continue
 				}
-				s.pieces.Set(m.Piece().ID(), NewPiece(
-					m.Piece().ID(),
-					m.Piece().Life()+li,
-					m.Piece().Damage()+di,
-				))
+				a, _ := s.board.Piece(h.attacker)
+				s.levelUp(a)
+				leveled[h.attacker] = true
 			}
-			switch s.NextPlayer() {
-			case Player1:
-				s.player1PiecesAlive--
-			case Player2:
-				s.player2PiecesAlive--
+			s.destroyPiece(p)
+			s.piecesAlive[id]--
+			if s.piecesAlive[id] == 0 {
+				s.eliminated = append(s.eliminated, id)
 			}
-			s.pieces.Set(p.ID(), NoPiece)
-			s.piecesToCells.Set(p, NoCell)
 		}
 	}
 }
 
-// applyMove applies the single Move to the State.
-func applyMove(s *State, m Move) {
-	previous := s.CellForPiece(m.Piece())
-	next := nextCell(previous, m.Direction())
-	if pid, ok := s.cellsToPieceIDs.Get(next); ok {
+// forfeit the game for the Player with the PlayerID by eliminating them and
+// removing their Pieces from the board.
+func (s *State) forfeit(id PlayerID) {
+	s.forfeited = id
+	if s.piecesAlive[id] == 0 {
+		return
+	}
+	for _, p := range s.board.PlayerPieces(id) {
+		s.destroyPiece(p)
+	}
+	s.piecesAlive[id] = 0
+	s.eliminated = append(s.eliminated, id)
+	if s.CurrentPlayer() == id {
+		s.setCurrentPlayer(s.NextPlayer())
+	}
+	// Removed Pieces can't come back, so no earlier position can repeat.
+	s.positions = nil
+	s.recordPosition()
+}
+
+// allowance returns how many Moves the Piece with the PieceID can make in a
+// Play.
+func (s *State) allowance(pid PieceID) int {
+	p, _ := s.board.Piece(pid)
+	return p.Class().Moves()
+}
+
+// applyMove applies the single Move to the State and returns the PieceID of
+// the Piece it attacked or NoPieceID if it didn't attack.
+func applyMove(s *State, m Move) PieceID {
+	mover, ok := s.board.Piece(m.Piece().ID())
+	if !ok {
+		return NoPieceID
+	}
+	previous := s.CellForPiece(mover)
+	next := offsetCell(previous, m.Offset())
+	e := Event{Piece: mover.ID(), From: previous, To: next}
+	if !s.Rules().IsOnBoard(next) || s.Rules().IsBlocked(next) ||
+		!isPatternStep(s, mover.ID(), previous, m.Offset()) {
+		e.Kind = BlockedEvent
+		s.emit(e)
+		return NoPieceID
+	}
+	if pid, ok := s.board.PieceID(next); ok {
 		if s.playerForPieceID(pid) == s.CurrentPlayer() {
-			return
+			e.Kind = BlockedEvent
+			s.emit(e)
+			return NoPieceID
 		}
 	}
-	if p := s.PieceForCell(next); s.PlayerForPiece(p) == s.NextPlayer() {
-		s.pieces.Set(p.ID(), NewPiece(
-			p.ID(),
-			p.Life()-m.Piece().Damage(),
-			p.Damage(),
-		))
-		return
+	if p := s.PieceForCell(next); s.PlayerForPiece(p) != NoPlayer {
+		p = p.withStats(p.Life()-mover.Damage(), p.Damage())
+		s.setPiece(p.ID(), p)
+		e.Kind, e.Target = AttackedEvent, p.ID()
+		e.Life, e.Damage = p.Life(), mover.Damage()
+		s.emit(e)
+		// Damage can't be undone, so no earlier position can repeat.
+		s.positions = nil
+		return p.ID()
+	}
+	s.movePiece(m.Piece().ID(), previous, next)
+	e.Kind = MovedEvent
+	s.emit(e)
+	return NoPieceID
+}
+
+// setPiece replaces the Piece with the PieceID with the given Piece and updates
+// the State's hash.
+func (s *State) setPiece(pid PieceID, p Piece) {
+	old, _ := s.board.Piece(pid)
+	s.hash ^= hashPiece(old) ^ hashPiece(p)
+	s.board.SetPiece(pid, p)
+}
+
+// movePiece with the PieceID from one Cell to another and updates the State's
+// hash.
+func (s *State) movePiece(pid PieceID, from, to Cell) {
+	s.hash ^= hashCell(pid, from) ^ hashCell(pid, to)
+	s.board.SetCell(pid, to)
+	s.board.SetPieceID(to, pid)
+	s.board.RemovePieceID(from)
+	s.claim(to, s.playerForPieceID(pid))
+}
+
+// destroyPiece removes the Piece from the State and its Cell and updates the
+// State's hash.
+func (s *State) destroyPiece(p Piece) {
+	c := s.CellForPiece(p)
+	if c != NoCell {
+		s.hash ^= hashCell(p.ID(), c)
+		s.board.RemovePieceID(c)
 	}
-	s.piecesToCells.Set(m.Piece(), next)
-	s.cellsToPieceIDs.Set(next, m.Piece().ID())
-	s.cellsToPieceIDs.Remove(previous)
+	s.setPiece(p.ID(), NoPiece)
+	s.board.SetCell(p.ID(), NoCell)
+}
+
+// relocate the Pieces with the PieceIDs to the Cells at the same index all at
+// once and updates the State's hash.
+//
+// Pieces can be relocated into Cells other relocated Pieces are leaving.
+func (s *State) relocate(pids []PieceID, to []Cell) {
+	from := make([]Cell, len(pids))
+	for i, pid := range pids {
+		from[i] = s.CellForPiece(NewPiece(pid, 0, 0))
+		s.hash ^= hashCell(pid, from[i])
+		s.board.RemovePieceID(from[i])
+	}
+	for i, pid := range pids {
+		s.hash ^= hashCell(pid, to[i])
+		s.board.SetCell(pid, to[i])
+		s.board.SetPieceID(to[i], pid)
+		s.claim(to[i], s.playerForPieceID(pid))
+	}
+}
+
+// setCurrentPlayer to the PlayerID and updates the State's hash.
+func (s *State) setCurrentPlayer(id PlayerID) {
+	s.hash ^= hashPlayer(s.currentPlayer) ^ hashPlayer(id)
+	s.currentPlayer = id
+}
+
+// position is a record of a position which occurred in a game linked to the
+// positions which occurred before it since the last time a Piece was damaged.
+type position struct {
+	key      uint64
+	count    int
+	previous *position
 }
 
-// removePiece occurances in list of Pieces.
-func removePiece(ps []Piece, r Piece) []Piece {
-	var out []Piece
-	for _, p := range ps {
-		if p != r {
-			out = append(out, p)
+// recordPosition adds the State's current position to its record of
+// positions.
+func (s *State) recordPosition() {
+	p := &position{key: s.Hash(), count: 1, previous: s.positions}
+	for prev := p.previous; prev != nil; prev = prev.previous {
+		if prev.key == p.key {
+			p.count = prev.count + 1
+			break
 		}
 	}
-	return out
+	s.positions = p
 }
@@ -1,6 +1,9 @@
 package game
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func BenchmarkNextCell(b *testing.B) {
 	c := NewCell(0, 0)
@@ -12,3 +15,26 @@ func BenchmarkNextCell(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkClone benchmarks the efficiency of cloning a State.
+func BenchmarkClone(b *testing.B) {
+	s := NewState(StandardRules, nil, nil)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		clone(s)
+	}
+}
+
+// TestValidateSharedCells tests that Validate rejects States where Pieces share
+// a Cell.
+func TestValidateSharedCells(t *testing.T) {
+	t.Parallel()
+	s := NewState(StandardRules, nil, nil)
+	p1, p2 := s.Player1Pieces()[0], s.Player1Pieces()[1]
+	c := s.CellForPiece(p1)
+	s.board.SetCell(p2.ID(), c)
+	var pe *PieceError
+	if err := Validate(s); !errors.As(err, &pe) || pe.Cell != c {
+		t.Errorf("Validate(s) = %v, want a piece in cell %v", err, c)
+	}
+}
@@ -1,8 +1,10 @@
 package game_test
 
 import (
+	"context"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/jwowillo/landgrab/game"
 )
@@ -53,3 +55,264 @@ func BenchmarkNextStateWithPlay(b *testing.B) {
 		game.NextStateWithPlay(s, p)
 	}
 }
+
+// TestDrawByMaxTurns tests that a game.State is a draw once the game.Rules'
+// max turns have been played without a winner.
+func TestDrawByMaxTurns(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(30*time.Second, 1, 1, 1, 1, 1).WithMaxTurns(3)
+	s := game.NewState(r, normal1{}, normal2{})
+	for i := 0; i < 3; i++ {
+		if s.IsOver() {
+			t.Fatalf("s.IsOver() = %v at turn %d, want %v", true, i, false)
+		}
+		s = game.NextState(s)
+	}
+	if s.Turn() != 3 {
+		t.Errorf("s.Turn() = %d, want %d", s.Turn(), 3)
+	}
+	if !s.IsDraw() || !s.IsOver() {
+		t.Errorf("s.IsDraw() = %v, want %v", s.IsDraw(), true)
+	}
+	if n := game.NextState(s); n.Turn() != s.Turn() {
+		t.Errorf("n.Turn() = %d, want %d", n.Turn(), s.Turn())
+	}
+}
+
+// TestDrawByRepetition tests that a game.State is a draw once the same
+// position has occurred the game.Rules' repetition limit times.
+func TestDrawByRepetition(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(30*time.Second, 1, 1, 1, 1, 1)
+	s := game.NewState(r, normal1{}, normal2{})
+	p1 := s.Player1Pieces()[0]
+	p2 := s.Player2Pieces()[0]
+	cycle := []game.Play{
+		{game.NewMove(p1, game.East)},
+		{game.NewMove(p2, game.East)},
+		{game.NewMove(p1, game.West)},
+		{game.NewMove(p2, game.West)},
+	}
+	for i := 1; i < r.RepetitionLimit(); i++ {
+		if s.IsDraw() {
+			t.Fatalf("s.IsDraw() = %v after %d cycles", true, i-1)
+		}
+		for _, p := range cycle {
+			s = game.NextStateWithPlay(s, p)
+		}
+	}
+	if !s.IsDraw() {
+		t.Errorf("s.IsDraw() = %v, want %v", s.IsDraw(), true)
+	}
+	if s.Winner() != game.NoPlayer {
+		t.Errorf("s.Winner() = %v, want %v", s.Winner(), game.NoPlayer)
+	}
+}
+
+// TestNextStateTimeout tests that game.NextState makes game.Players who take
+// longer than the game.Rules' timer duration make an empty game.Play or forfeit
+// depending on the game.Rules' timeout policy.
+func TestNextStateTimeout(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	defer close(release)
+	p1 := slow{release: release}
+	r := game.NewRules(time.Millisecond, 1, 1, 1, 1, 1)
+	s := game.NextState(game.NewState(r, p1, normal2{}))
+	if s.TimedOut() != game.Player1 {
+		t.Errorf(
+			"s.TimedOut() = %v, want %v",
+			s.TimedOut(), game.Player1,
+		)
+	}
+	if s.IsOver() || s.CurrentPlayer() != game.Player2 {
+		t.Errorf("game.NextState(s) doesn't make an empty play")
+	}
+	if n := game.NextState(s); n.TimedOut() != game.NoPlayer {
+		t.Errorf(
+			"n.TimedOut() = %v, want %v",
+			n.TimedOut(), game.NoPlayer,
+		)
+	}
+	r = r.WithTimeoutPolicy(game.ForfeitOnTimeout)
+	s = game.NextState(game.NewState(r, p1, normal2{}))
+	if s.Forfeited() != game.Player1 {
+		t.Errorf(
+			"s.Forfeited() = %v, want %v",
+			s.Forfeited(), game.Player1,
+		)
+	}
+	if s.Winner() != game.Player2 {
+		t.Errorf("s.Winner() = %v, want %v", s.Winner(), game.Player2)
+	}
+}
+
+// TestNextStateContext tests that game.NextStateContext stops game.Players once
+// the context.Context is done and leaves the game.State unchanged.
+func TestNextStateContext(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(0, 1, 1, 1, 1, 1)
+	s := game.NewState(r, cancelling{}, normal2{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	n, err := game.NextStateContext(ctx, s)
+	if n != s || err != context.Canceled {
+		t.Errorf(
+			"game.NextStateContext(ctx, s) = %v, %v, want %v, %v",
+			n, err, s, context.Canceled,
+		)
+	}
+	r = game.NewRules(time.Millisecond, 1, 1, 1, 1, 1)
+	s = game.NewState(r, cancelling{}, normal2{})
+	n, err = game.NextStateContext(context.Background(), s)
+	if err != nil || n.TimedOut() != game.Player1 {
+		t.Errorf(
+			"game.NextStateContext(ctx, s) = %v, %v, want timeout",
+			n, err,
+		)
+	}
+}
+
+// slow game.Player which doesn't return a game.Play until released.
+type slow struct {
+	release chan struct{}
+}
+
+// Play after being released.
+func (p slow) Play(s *game.State) game.Play {
+	<-p.release
+	return nil
+}
+
+// TestNewStateRectangular tests that game.NewState spreads game.Pieces along
+// opposite sides of boards of any dimensions.
+func TestNewStateRectangular(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Width, Height, PieceCount int
+		Player1Cells              []game.Cell
+	}{
+		{
+			Width: 11, Height: 11, PieceCount: 5,
+			Player1Cells: []game.Cell{
+				game.NewCell(0, 1), game.NewCell(0, 3),
+				game.NewCell(0, 5), game.NewCell(0, 7),
+				game.NewCell(0, 9),
+			},
+		},
+		{
+			Width: 4, Height: 9, PieceCount: 2,
+			Player1Cells: []game.Cell{
+				game.NewCell(0, 1), game.NewCell(0, 3),
+			},
+		},
+		{
+			Width: 2, Height: 6, PieceCount: 3,
+			Player1Cells: []game.Cell{
+				game.NewCell(0, 0), game.NewCell(0, 1),
+				game.NewCell(1, 1),
+			},
+		},
+	}
+	for _, test := range cases {
+		r := game.NewRules(30*time.Second, test.PieceCount, 1, 1, 1, 1)
+		r = r.WithBoardSize(test.Width, test.Height)
+		s := game.NewState(r, normal1{}, normal2{})
+		p1s := s.Player1Pieces()
+		p2s := s.Player2Pieces()
+		if len(p1s) != test.PieceCount || len(p2s) != test.PieceCount {
+			t.Fatalf(
+				"len(s.Player1Pieces()) = %d, want %d",
+				len(p1s), test.PieceCount,
+			)
+		}
+		for i, c := range test.Player1Cells {
+			if s.CellForPiece(p1s[i]) != c {
+				t.Errorf(
+					"s.CellForPiece(%v) = %v, want %v",
+					p1s[i], s.CellForPiece(p1s[i]), c,
+				)
+			}
+			m := game.NewCell(test.Height-1-c.Row(), c.Column())
+			if s.CellForPiece(p2s[i]) != m {
+				t.Errorf(
+					"s.CellForPiece(%v) = %v, want %v",
+					p2s[i], s.CellForPiece(p2s[i]), m,
+				)
+			}
+		}
+	}
+}
+
+// TestFreeForAll tests that game.States with more than 2 game.Players start
+// with every game.Player on their own side, rotate through the game.Players
+// who haven't been eliminated, and record the order they were eliminated in.
+func TestFreeForAll(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(0, 1, 1, 1, 0, 0).WithBoardSize(3, 3)
+	r = r.WithPlayerCount(4)
+	s := game.NewStateWithPlayers(r, []game.Player{
+		normal1{}, normal2{}, normal1{}, normal2{},
+	})
+	starts := map[game.PlayerID]game.Cell{
+		game.Player1: game.NewCell(0, 1),
+		game.Player2: game.NewCell(2, 1),
+		game.Player3: game.NewCell(1, 0),
+		game.Player4: game.NewCell(1, 2),
+	}
+	for id, c := range starts {
+		ps := s.PlayerPieces(id)
+		if len(ps) != 1 || s.CellForPiece(ps[0]) != c {
+			t.Fatalf(
+				"s.PlayerPieces(%v) = %v, want 1 Piece in %v",
+				id, ps, c,
+			)
+		}
+	}
+	for _, id := range []game.PlayerID{
+		game.Player2, game.Player3, game.Player4, game.Player1,
+	} {
+		s = game.NextStateWithPlay(s, nil)
+		if s.CurrentPlayer() != id {
+			t.Fatalf(
+				"s.CurrentPlayer() = %v, want %v",
+				s.CurrentPlayer(), id,
+			)
+		}
+	}
+	moves := []struct {
+		Player    game.PlayerID
+		Direction game.Direction
+		Next      game.PlayerID
+	}{
+		{game.Player1, game.SouthWest, game.Player2},
+		{game.Player2, game.NorthEast, game.Player1},
+		{game.Player1, game.South, game.Player2},
+		{game.Player2, game.North, game.Player2},
+	}
+	for _, m := range moves {
+		p := s.PlayerPieces(m.Player)[0]
+		s = game.NextStateWithPlay(s, game.Play{
+			game.NewMove(p, m.Direction),
+		})
+		if s.CurrentPlayer() != m.Next {
+			t.Fatalf(
+				"s.CurrentPlayer() = %v, want %v",
+				s.CurrentPlayer(), m.Next,
+			)
+		}
+	}
+	want := []game.PlayerID{game.Player3, game.Player4, game.Player1}
+	got := s.Eliminated()
+	if len(got) != len(want) {
+		t.Fatalf("s.Eliminated() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("s.Eliminated() = %v, want %v", got, want)
+		}
+	}
+	if s.Winner() != game.Player2 {
+		t.Errorf("s.Winner() = %v, want %v", s.Winner(), game.Player2)
+	}
+}
@@ -0,0 +1,194 @@
+package game
+
+import (
+	"iter"
+	"slices"
+)
+
+// DistinctLegalPlays returns a legal Play for the State's current Player for
+// each of the distinct positions the Plays lead to.
+//
+// The Plays are the ones DistinctOutcomes makes.
+func DistinctLegalPlays(s *State) []Play {
+	var ps []Play
+	for p := range DistinctOutcomes(s) {
+		ps = append(ps, p)
+	}
+	return ps
+}
+
+// DistinctOutcomes returns a sequence which makes the legal Plays for the
+// State's current Player along with the State each leads to, skipping Plays
+// which lead to a position an earlier Play already led to.
+//
+// Positions are looked up by their Hash and compared in full when Hashes
+// match, so Hash collisions never skip a Play. If the State IsMirrorSymmetric,
+// Plays which lead to the mirror image of a position an earlier Play led to
+// are skipped as well since the positions are just as good. The Plays are
+// otherwise made in the order LegalPlaySeq makes them and aren't reused.
+func DistinctOutcomes(s *State) iter.Seq2[Play, *State] {
+	return func(yield func(Play, *State) bool) {
+		partners, symmetric := mirrorPartners(s)
+		o := newOutcomes(partners, symmetric)
+		for p := range LegalPlaySeq(s) {
+			n := NextStateWithPlay(s, p)
+			if !o.add(n) {
+				continue
+			}
+			if !yield(append(Play(nil), p...), n) {
+				return
+			}
+		}
+	}
+}
+
+// outcomes are the positions DistinctOutcomes already led to grouped by Hash.
+type outcomes struct {
+	partners  []PieceID
+	symmetric bool
+	seen      map[uint64][]*State
+}
+
+// newOutcomes with no positions where positions are also the same as their
+// mirror images with the partners iff symmetric is true.
+func newOutcomes(partners []PieceID, symmetric bool) outcomes {
+	return outcomes{
+		partners:  partners,
+		symmetric: symmetric,
+		seen:      make(map[uint64][]*State),
+	}
+}
+
+// add the position of the State and return true iff it isn't the same as one
+// already added.
+func (o outcomes) add(s *State) bool {
+	for _, t := range o.seen[s.Hash()] {
+		if samePosition(s, t) {
+			return false
+		}
+	}
+	if o.symmetric {
+		for _, t := range o.seen[mirrorHash(s, o.partners)] {
+			if isMirrorImage(s, t, o.partners) {
+				return false
+			}
+		}
+	}
+	o.seen[s.Hash()] = append(o.seen[s.Hash()], s)
+	return true
+}
+
+// samePosition returns true iff the States have the same position as described
+// for Hash.
+func samePosition(a, b *State) bool {
+	return a.CurrentPlayer() == b.CurrentPlayer() &&
+		slices.Equal(a.board.data, b.board.data)
+}
+
+// isMirrorImage returns true iff the position of State b is the position of
+// State a reflected across the vertical axis of the board with each Piece
+// becoming its partner.
+func isMirrorImage(a, b *State, partners []PieceID) bool {
+	r := a.Rules()
+	if a.CurrentPlayer() != b.CurrentPlayer() ||
+		len(a.Pieces()) != len(b.Pieces()) {
+		return false
+	}
+	for _, p := range a.Pieces() {
+		q, ok := b.board.Piece(partners[p.ID()-1])
+		if !ok || q.Life() != p.Life() || q.Damage() != p.Damage() ||
+			b.CellForPiece(q) != mirrorCell(r, a.CellForPiece(p)) {
+			return false
+		}
+	}
+	if r.TerritoryControl() == 0 {
+		return true
+	}
+	for i := 0; i < r.BoardHeight(); i++ {
+		for j := 0; j < r.BoardWidth(); j++ {
+			c := NewCell(i, j)
+			if b.Owner(mirrorCell(r, c)) != a.Owner(c) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IsMirrorSymmetric returns true iff the State's position is the same when
+// reflected across the vertical axis of the board.
+//
+// Reflected positions have every Piece in the mirror image of a Cell held by
+// a Piece of the same Player with the same life, damage, and Class and every
+// Cell owned by the owner of its mirror image. The Rules' Terrain and Pattern
+// must also be symmetric. Deployment phases and
+// PlayerViews are never symmetric.
+func IsMirrorSymmetric(s *State) bool {
+	_, ok := mirrorPartners(s)
+	return ok
+}
+
+// mirrorPartners returns the PieceID of the Piece each Piece is reflected onto
+// indexed by PieceID minus 1 and true iff the State IsMirrorSymmetric.
+func mirrorPartners(s *State) ([]PieceID, bool) {
+	r := s.Rules()
+	if s.IsDeploying() || s.Viewer() != NoPlayer {
+		return nil, false
+	}
+	for _, c := range r.Terrain().Blocked() {
+		if !r.IsBlocked(mirrorCell(r, c)) {
+			return nil, false
+		}
+	}
+	pattern := r.Pattern()
+	for _, o := range pattern.offsets {
+		if !pattern.Allows(NewOffset(o.Rows(), -o.Columns())) {
+			return nil, false
+		}
+	}
+	for i := 0; i < r.BoardHeight(); i++ {
+		for j := 0; j < r.BoardWidth(); j++ {
+			c := NewCell(i, j)
+			if s.Owner(c) != s.Owner(mirrorCell(r, c)) {
+				return nil, false
+			}
+		}
+	}
+	partners := make([]PieceID, r.PieceCount()*r.PlayerCount())
+	for _, p := range s.Pieces() {
+		q := s.PieceForCell(mirrorCell(r, s.CellForPiece(p)))
+		if q.Life() != p.Life() || q.Damage() != p.Damage() ||
+			q.Class() != p.Class() ||
+			s.PlayerForPiece(q) != s.PlayerForPiece(p) {
+			return nil, false
+		}
+		partners[p.ID()-1] = q.ID()
+	}
+	return partners, true
+}
+
+// mirrorCell returns the Cell reflected across the vertical axis of the board.
+func mirrorCell(r Rules, c Cell) Cell {
+	return NewCell(c.Row(), r.BoardWidth()-1-c.Column())
+}
+
+// mirrorHash returns the Hash the State would have if it were reflected across
+// the vertical axis of the board with each Piece becoming its partner.
+func mirrorHash(s *State, partners []PieceID) uint64 {
+	h := hashPlayer(s.CurrentPlayer())
+	for _, p := range s.Pieces() {
+		q := partners[p.ID()-1]
+		h ^= hashPiece(NewPiece(q, p.Life(), p.Damage()))
+		h ^= hashCell(q, mirrorCell(s.Rules(), s.CellForPiece(p)))
+	}
+	if s.Rules().TerritoryControl() == 0 {
+		return h
+	}
+	for i := 0; i < s.Rules().BoardHeight(); i++ {
+		for j := 0; j < s.Rules().BoardWidth(); j++ {
+			c := NewCell(i, j)
+			h ^= hashOwner(mirrorCell(s.Rules(), c), s.Owner(c))
+		}
+	}
+	return h
+}
@@ -0,0 +1,105 @@
+package game_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// BenchmarkDistinctLegalPlays benchmarks the performance of finding the
+// game.Plays which lead to distinct positions from a game.State.
+func BenchmarkDistinctLegalPlays(b *testing.B) {
+	s := game.NewState(game.StandardRules, nil, nil)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		game.DistinctLegalPlays(s)
+	}
+}
+
+// TestDistinctLegalPlays tests that game.DistinctLegalPlays makes a legal
+// game.Play for every distinct position and skips mirror images only when
+// the game.State is mirror-symmetric.
+func TestDistinctLegalPlays(t *testing.T) {
+	t.Parallel()
+	scouts, _ := scoutState()
+	single := game.NewState(
+		game.NewRules(time.Second, 1, 1, 1, 1, 1),
+		nil, nil,
+	)
+	cases := []struct {
+		name      string
+		state     *game.State
+		symmetric bool
+		distinct  int
+	}{
+		// The scout can end in any of the 8 open game.Cells or attack
+		// from the center, often by more than one route.
+		{name: "scouts", state: scouts, distinct: 9},
+		// Moving east and west and moving south-east and south-west
+		// are mirror images.
+		{name: "single", state: single, symmetric: true, distinct: 4},
+		{
+			name: "moved",
+			state: game.NextStateWithPlay(game.NextStateWithPlay(
+				single,
+				game.Play{game.NewMove(
+					game.NewPiece(1, 1, 1),
+					game.East,
+				)},
+			), game.Play{}),
+			distinct: 4,
+		},
+		{
+			name:      "standard",
+			state:     game.NewState(game.StandardRules, nil, nil),
+			symmetric: true,
+		},
+	}
+	for _, c := range cases {
+		s := c.state
+		if game.IsMirrorSymmetric(s) != c.symmetric {
+			t.Errorf(
+				"%s: game.IsMirrorSymmetric(s) = %v, want %v",
+				c.name, !c.symmetric, c.symmetric,
+			)
+		}
+		all := make(map[uint64]bool)
+		for _, p := range game.LegalPlays(s) {
+			all[game.NextStateWithPlay(s, p).Hash()] = true
+		}
+		ps := game.DistinctLegalPlays(s)
+		seen := make(map[uint64]bool)
+		for _, p := range ps {
+			h := game.NextStateWithPlay(s, p).Hash()
+			if !game.IsLegalPlay(s, p) || seen[h] {
+				t.Errorf(
+					"%s: %v is illegal or a duplicate",
+					c.name, p,
+				)
+			}
+			seen[h] = true
+		}
+		if !c.symmetric && len(ps) != len(all) {
+			t.Errorf(
+				"%s: len(game.DistinctLegalPlays(s)) = %d, "+
+					"want %d",
+				c.name, len(ps), len(all),
+			)
+		}
+		if c.distinct != 0 && len(ps) != c.distinct {
+			t.Errorf(
+				"%s: len(game.DistinctLegalPlays(s)) = %d, "+
+					"want %d",
+				c.name, len(ps), c.distinct,
+			)
+		}
+		if c.symmetric && len(ps) >= len(all) {
+			t.Errorf(
+				"%s: len(game.DistinctLegalPlays(s)) = %d, "+
+					"want fewer than %d",
+				c.name, len(ps), len(all),
+			)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Terrain of a board which marks Cells as blocked.
+//
+// Pieces can't enter blocked Cells. All other Cells are open.
+//
+// Terrain is immutable once created.
+type Terrain struct {
+	blocked map[Cell]struct{}
+}
+
+// NewTerrain where the given Cells are blocked.
+func NewTerrain(blocked ...Cell) *Terrain {
+	t := &Terrain{blocked: make(map[Cell]struct{}, len(blocked))}
+	for _, c := range blocked {
+		t.blocked[c] = struct{}{}
+	}
+	return t
+}
+
+// Terrain map symbols.
+const (
+	OpenSymbol    = '.'
+	BlockedSymbol = '#'
+)
+
+// ParseTerrain from a map where each string is a row of the board and each
+// character is a Cell in the row.
+//
+// Blocked Cells are marked with BlockedSymbol and open Cells with OpenSymbol or
+// a Player's number as used by ParseLayout. An error is returned if any other
+// character is used.
+func ParseTerrain(rows []string) (*Terrain, error) {
+	var blocked []Cell
+	for i, row := range rows {
+		for j, x := range []rune(row) {
+			if _, ok := layoutPlayer(x); ok {
+				continue
+			}
+			switch x {
+			case OpenSymbol:
+			case BlockedSymbol:
+				blocked = append(blocked, NewCell(i, j))
+			default:
+				return nil, fmt.Errorf(
+					"invalid terrain symbol %q at row %d "+
+						"column %d",
+					x, i, j,
+				)
+			}
+		}
+	}
+	return NewTerrain(blocked...), nil
+}
+
+// ReadMap reads the rows of a map in the form accepted by ParseTerrain with 1
+// row per line.
+//
+// Blank lines and surrounding whitespace are ignored.
+func ReadMap(r io.Reader) ([]string, error) {
+	var rows []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if row := strings.TrimSpace(scanner.Text()); row != "" {
+			rows = append(rows, row)
+		}
+	}
+	return rows, scanner.Err()
+}
+
+// ReadMapFile returns StandardRules with the map read with ReadMap from the
+// file at the path as described for Rules.WithMap.
+func ReadMapFile(path string) (Rules, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return StandardRules, err
+	}
+	defer f.Close()
+	rows, err := ReadMap(f)
+	if err != nil {
+		return StandardRules, err
+	}
+	return StandardRules.WithMap(rows)
+}
+
+// IsBlocked returns true iff the Cell is blocked.
+//
+// No Cells are blocked in nil Terrain.
+func (t *Terrain) IsBlocked(c Cell) bool {
+	if t == nil {
+		return false
+	}
+	_, ok := t.blocked[c]
+	return ok
+}
+
+// Blocked Cells of the Terrain ordered by row and then column.
+func (t *Terrain) Blocked() []Cell {
+	if t == nil {
+		return nil
+	}
+	cs := make([]Cell, 0, len(t.blocked))
+	for c := range t.blocked {
+		cs = append(cs, c)
+	}
+	sortCells(cs)
+	return cs
+}
+
+// Map of the Terrain for a board with the given width and height in the form
+// accepted by ParseTerrain.
+func (t *Terrain) Map(w, h int) []string {
+	rows := make([]string, h)
+	for i := range rows {
+		row := make([]rune, w)
+		for j := range row {
+			row[j] = OpenSymbol
+			if t.IsBlocked(NewCell(i, j)) {
+				row[j] = BlockedSymbol
+			}
+		}
+		rows[i] = string(row)
+	}
+	return rows
+}
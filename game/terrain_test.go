@@ -0,0 +1,133 @@
+package game_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// TestParseTerrain tests that game.ParseTerrain blocks the marked game.Cells,
+// rejects unknown symbols, and that game.Terrain maps back to the same rows.
+func TestParseTerrain(t *testing.T) {
+	t.Parallel()
+	rows := []string{
+		"..#",
+		"#..",
+	}
+	terrain, err := game.ParseTerrain(rows)
+	if err != nil {
+		t.Fatalf(
+			"game.ParseTerrain(rows) error = %v, want %v",
+			err, nil,
+		)
+	}
+	want := []game.Cell{game.NewCell(0, 2), game.NewCell(1, 0)}
+	if !reflect.DeepEqual(terrain.Blocked(), want) {
+		t.Errorf(
+			"terrain.Blocked() = %v, want %v",
+			terrain.Blocked(), want,
+		)
+	}
+	if m := terrain.Map(3, 2); !reflect.DeepEqual(m, rows) {
+		t.Errorf("terrain.Map(3, 2) = %v, want %v", m, rows)
+	}
+	if _, err := game.ParseTerrain([]string{".x."}); err == nil {
+		t.Errorf("game.ParseTerrain(rows) error = %v, want error", err)
+	}
+	var none *game.Terrain
+	if none.IsBlocked(game.NewCell(0, 0)) {
+		t.Errorf("nil game.Terrain blocks game.Cells")
+	}
+}
+
+// TestRulesWithMap tests that game.Rules.WithMap sets the board size and
+// game.Terrain from a map read with game.ReadMap and rejects maps which block
+// the game.Cells game.Pieces start in.
+func TestRulesWithMap(t *testing.T) {
+	t.Parallel()
+	rows, err := game.ReadMap(strings.NewReader("\n #.... \n.....\n\n"))
+	if err != nil {
+		t.Fatalf("game.ReadMap(r) error = %v, want %v", err, nil)
+	}
+	r, err := game.NewRules(time.Second, 1, 1, 1, 1, 1).WithMap(rows)
+	if err != nil {
+		t.Fatalf("r.WithMap(rows) error = %v, want %v", err, nil)
+	}
+	if r.BoardWidth() != 5 || r.BoardHeight() != 2 {
+		t.Errorf(
+			"r.BoardWidth(), r.BoardHeight() = %d, %d, want %d, %d",
+			r.BoardWidth(), r.BoardHeight(), 5, 2,
+		)
+	}
+	if !r.IsBlocked(game.NewCell(0, 0)) {
+		t.Errorf(
+			"r.IsBlocked(%v) = %v, want %v",
+			game.NewCell(0, 0), false, true,
+		)
+	}
+	for _, bad := range [][]string{
+		nil, {"..", "."}, {"?"}, {"..#..", "....."},
+	} {
+		if _, err := r.WithMap(bad); err == nil {
+			t.Errorf(
+				"r.WithMap(%v) error = %v, want error",
+				bad, err,
+			)
+		}
+	}
+}
+
+// TestRulesWithCheckedTerrain tests that game.Rules.WithCheckedTerrain rejects
+// game.Terrain which blocks the game.Cells game.Pieces start in.
+func TestRulesWithCheckedTerrain(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(time.Second, 1, 1, 1, 1, 1)
+	open := game.NewTerrain(game.NewCell(1, 1))
+	if got, err := r.WithCheckedTerrain(open); err != nil ||
+		!got.IsBlocked(game.NewCell(1, 1)) {
+		t.Errorf(
+			"r.WithCheckedTerrain(open) error = %v, want %v",
+			err, nil,
+		)
+	}
+	start := game.NewTerrain(game.NewCell(0, 1))
+	if got, err := r.WithCheckedTerrain(start); err == nil ||
+		got.IsBlocked(game.NewCell(0, 1)) {
+		t.Errorf(
+			"r.WithCheckedTerrain(start) error = %v, want error",
+			err,
+		)
+	}
+}
+
+// TestBlockedCells tests that game.Pieces can't move into game.Cells blocked
+// by game.Terrain.
+func TestBlockedCells(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(30*time.Second, 1, 1, 1, 1, 1)
+	blocked := []game.Cell{game.NewCell(1, 1), game.NewCell(0, 0)}
+	r = r.WithTerrain(game.NewTerrain(blocked...))
+	s := game.NewState(r, normal1{}, normal2{})
+	p := s.Player1Pieces()[0]
+	for _, d := range []game.Direction{game.South, game.West} {
+		if m := game.NewMove(p, d); game.IsLegalMove(s, m) {
+			t.Errorf(
+				"game.IsLegalMove(s, %v) = %v, want %v",
+				m, true, false,
+			)
+		}
+	}
+	if n := len(game.LegalPlays(s)); n != 4 {
+		t.Errorf("len(game.LegalPlays(s)) = %d, want %d", n, 4)
+	}
+	n := game.NextStateWithPlay(s, game.Play{game.NewMove(p, game.South)})
+	if c := n.CellForPiece(p); c != s.CellForPiece(p) {
+		t.Errorf(
+			"n.CellForPiece(p) = %v, want %v",
+			c, s.CellForPiece(p),
+		)
+	}
+}
@@ -0,0 +1,128 @@
+package game
+
+// WithTerritoryControl returns a copy of the Rules where Cells are owned by the
+// last Player to have a Piece in them and Players win by owning the percent of
+// the open Cells.
+//
+// Once the Rules' MaxTurns have been played, the Player who owns the most Cells
+// wins instead of the game being a draw. Players who have been eliminated
+// can't win by territory. A percent of zero or less turns territory control
+// off and percents above 100 are clamped to 100.
+func (r Rules) WithTerritoryControl(percent int) Rules {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	r.territoryControl = percent
+	return r
+}
+
+// TerritoryControl is the percent of the open Cells a Player must own to win
+// or zero if there is no territory control.
+func (r Rules) TerritoryControl() int {
+	return r.territoryControl
+}
+
+// OpenCells is the number of Cells on the board which aren't blocked.
+func (r Rules) OpenCells() int {
+	n := r.BoardWidth() * r.BoardHeight()
+	if r.terrain != nil {
+		for c := range r.terrain.blocked {
+			if r.IsOnBoard(c) {
+				n--
+			}
+		}
+	}
+	return n
+}
+
+// Owner of the Cell or NoPlayer if no Player owns it or the Rules have no
+// territory control.
+func (s *State) Owner(c Cell) PlayerID {
+	return s.board.Owner(c)
+}
+
+// Territory is the number of Cells the Player with the PlayerID owns.
+func (s *State) Territory(id PlayerID) int {
+	if id <= NoPlayer || int(id) > MaxPlayerCount {
+		return 0
+	}
+	return s.territory[id]
+}
+
+// WithOwners returns a copy of the State where the Cells are owned by the
+// Players they're mapped to and all other Cells have no owner.
+//
+// This is meant for restoring games already in progress, such as ones created
+// with NewStateFromInfo. The State is returned unchanged if the Rules have no
+// territory control.
+func (s *State) WithOwners(owners map[Cell]PlayerID) *State {
+	if s.Rules().TerritoryControl() == 0 {
+		return s
+	}
+	s = clone(s)
+	for i := 0; i < s.Rules().BoardHeight(); i++ {
+		for j := 0; j < s.Rules().BoardWidth(); j++ {
+			c := NewCell(i, j)
+			s.claim(c, owners[c])
+		}
+	}
+	return s
+}
+
+// claim the Cell for the Player with the PlayerID if the Rules have territory
+// control and update the State's territory and hash.
+func (s *State) claim(c Cell, id PlayerID) {
+	if s.rules.territoryControl == 0 || !s.rules.IsOnBoard(c) {
+		return
+	}
+	old := s.board.Owner(c)
+	if old == id {
+		return
+	}
+	if old != NoPlayer {
+		s.territory[old]--
+	}
+	if id != NoPlayer {
+		s.territory[id]++
+	}
+	s.hash ^= hashOwner(c, old) ^ hashOwner(c, id)
+	s.board.SetOwner(c, id)
+}
+
+// territoryWinner returns the Player who has won by territory control or
+// NoPlayer if nobody has.
+//
+// The Player who hasn't been eliminated and owns the most Cells wins if they
+// own the Rules' TerritoryControl percent of the open Cells or the Rules'
+// MaxTurns have been played. Nobody wins while the most Cells are tied.
+func (s *State) territoryWinner() PlayerID {
+	r := s.Rules()
+	if r.TerritoryControl() == 0 {
+		return NoPlayer
+	}
+	leader, tied := NoPlayer, false
+	for _, id := range r.PlayerIDs() {
+		if s.piecesAlive[id] == 0 {
+			continue
+		}
+		switch t := s.territory[id]; {
+		case leader == NoPlayer || t > s.territory[leader]:
+			leader, tied = id, false
+		case t == s.territory[leader]:
+			tied = true
+		}
+	}
+	if leader == NoPlayer || tied {
+		return NoPlayer
+	}
+	if s.territory[leader]*100 >= r.TerritoryControl()*r.OpenCells() {
+		return leader
+	}
+	if mt := r.MaxTurns(); mt > 0 && s.Turn() >= mt {
+		return leader
+	}
+	return NoPlayer
+}
@@ -0,0 +1,194 @@
+package game_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// territoryRules for a 3 by 3 board with 9 open game.Cells where a
+// game.Player must own 5 to win by territory and games end after 10 turns.
+func territoryRules() game.Rules {
+	return game.NewRules(time.Second, 2, 3, 1, 1, 1).
+		WithBoardSize(3, 3).
+		WithMaxTurns(10).
+		WithTerritoryControl(50)
+}
+
+// territoryState where player 1 has a game.Piece in the top-left corner and
+// player 2 has one in the bottom-right corner with the given owners at the
+// turn.
+func territoryState(owners map[game.Cell]game.PlayerID, turn int) *game.State {
+	return game.NewStateFromInfo(
+		territoryRules(),
+		game.Player1,
+		nil, nil,
+		map[game.Cell]game.Piece{
+			game.NewCell(0, 0): game.NewPiece(1, 3, 1),
+			game.NewCell(2, 2): game.NewPiece(3, 3, 1),
+		},
+	).WithTurn(turn).WithOwners(owners)
+}
+
+// TestTerritoryControl tests that game.Cells are owned by the last
+// game.Player to move into them and that game.Players win by owning enough of
+// the board or the most of it once the game.Rules' MaxTurns are played.
+func TestTerritoryControl(t *testing.T) {
+	t.Parallel()
+	type owners = map[game.Cell]game.PlayerID
+	p1, p2 := game.Player1, game.Player2
+	top := owners{
+		game.NewCell(0, 0): p1,
+		game.NewCell(0, 1): p1,
+		game.NewCell(0, 2): p1,
+		game.NewCell(2, 2): p2,
+	}
+	withCenter := owners{game.NewCell(1, 1): p2}
+	withSides := owners{
+		game.NewCell(2, 1): p2,
+		game.NewCell(2, 0): p2,
+		game.NewCell(1, 2): p2,
+	}
+	merge := func(os ...owners) owners {
+		merged := make(owners)
+		for _, o := range os {
+			for c, id := range o {
+				merged[c] = id
+			}
+		}
+		return merged
+	}
+	cases := []struct {
+		name      string
+		owners    owners
+		turn      int
+		direction game.Direction
+		territory [2]int
+		winner    game.PlayerID
+		draw      bool
+	}{
+		{
+			name:      "owned",
+			owners:    top,
+			direction: game.East,
+			territory: [2]int{3, 1},
+		},
+		{
+			name:      "open",
+			owners:    top,
+			direction: game.South,
+			territory: [2]int{4, 1},
+		},
+		{
+			name: "taken to win",
+			owners: merge(
+				top, withCenter,
+				owners{game.NewCell(1, 0): p1},
+			),
+			direction: game.SouthEast,
+			territory: [2]int{5, 1},
+			winner:    p1,
+		},
+		{
+			name:      "most at max turns",
+			owners:    merge(top, withCenter),
+			turn:      9,
+			direction: game.South,
+			territory: [2]int{4, 2},
+			winner:    p1,
+		},
+		{
+			name:      "tied at max turns",
+			owners:    merge(top, withSides),
+			turn:      9,
+			direction: game.South,
+			territory: [2]int{4, 4},
+			draw:      true,
+		},
+	}
+	for _, c := range cases {
+		s := territoryState(c.owners, c.turn)
+		piece := s.Player1Pieces()[0]
+		// The game.Piece moves from the top-left corner.
+		o := c.direction.Offset()
+		to := game.NewCell(o.Rows(), o.Columns())
+		n := game.NextStateWithPlay(
+			s,
+			game.Play{game.NewMove(piece, c.direction)},
+		)
+		if n.Owner(to) != p1 {
+			t.Errorf(
+				"%s: n.Owner(%v) = %v, want %v",
+				c.name, to, n.Owner(to), p1,
+			)
+		}
+		territory := [2]int{n.Territory(p1), n.Territory(p2)}
+		if territory != c.territory {
+			t.Errorf(
+				"%s: territory = %v, want %v",
+				c.name, territory, c.territory,
+			)
+		}
+		if n.Winner() != c.winner || n.IsDraw() != c.draw {
+			t.Errorf(
+				"%s: n.Winner(), n.IsDraw() = %v, %v, "+
+					"want %v, %v",
+				c.name, n.Winner(), n.IsDraw(),
+				c.winner, c.draw,
+			)
+		}
+	}
+}
+
+// TestTerritoryStart tests that game.Players start owning the game.Cells
+// their game.Pieces start in only when the game.Rules have territory control.
+func TestTerritoryStart(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(time.Second, 2, 3, 1, 1, 1)
+	s := game.NewState(r.WithTerritoryControl(50), nil, nil)
+	for _, p := range s.Pieces() {
+		c := s.CellForPiece(p)
+		if id := s.PlayerForPiece(p); s.Owner(c) != id {
+			t.Errorf("s.Owner(%v) = %v, want %v", c, s.Owner(c), id)
+		}
+	}
+	if s.Territory(game.Player1) != 2 || s.Territory(game.Player2) != 2 {
+		t.Errorf(
+			"territory = %d, %d, want %d, %d",
+			s.Territory(game.Player1), s.Territory(game.Player2),
+			2, 2,
+		)
+	}
+	plain := game.NewState(r, nil, nil)
+	c := plain.CellForPiece(plain.Player1Pieces()[0])
+	if plain.Owner(c) != game.NoPlayer ||
+		plain.Territory(game.Player1) != 0 {
+		t.Errorf("game.Cells are owned without territory control")
+	}
+	if plain.WithOwners(nil) != plain {
+		t.Errorf("plain.WithOwners(nil) changed the game.State")
+	}
+}
+
+// TestTerritoryHash tests that game.States which differ only in who owns
+// game.Cells have different Hashes and that restoring the owners with
+// game.State.WithOwners restores the Hash.
+func TestTerritoryHash(t *testing.T) {
+	t.Parallel()
+	a := territoryState(map[game.Cell]game.PlayerID{
+		game.NewCell(1, 1): game.Player1,
+	}, 0)
+	b := territoryState(map[game.Cell]game.PlayerID{
+		game.NewCell(1, 1): game.Player2,
+	}, 0)
+	if a.Hash() == b.Hash() {
+		t.Errorf("a.Hash() = b.Hash(), want them to differ")
+	}
+	owners := map[game.Cell]game.PlayerID{
+		game.NewCell(1, 1): game.Player1,
+	}
+	if c := b.WithOwners(owners); c.Hash() != a.Hash() {
+		t.Errorf("c.Hash() = %d, want %d", c.Hash(), a.Hash())
+	}
+}
@@ -0,0 +1,259 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// PieceError describes why a Piece given for a State is invalid.
+type PieceError struct {
+	// Piece which is invalid.
+	Piece PieceID
+	// Cell the Piece was given in.
+	Cell Cell
+	// Reason the Piece is invalid.
+	Reason string
+}
+
+// Error names the Piece and Cell and gives the Reason.
+func (e *PieceError) Error() string {
+	return fmt.Sprintf("piece %d in cell %v %s", e.Piece, e.Cell, e.Reason)
+}
+
+// PlayerError describes why a Player given for a State is invalid.
+type PlayerError struct {
+	// Player which is invalid.
+	Player PlayerID
+	// Reason the Player is invalid.
+	Reason string
+}
+
+// Error names the Player and gives the Reason.
+func (e *PlayerError) Error() string {
+	return fmt.Sprintf("%v %s", e.Player, e.Reason)
+}
+
+// Limits on the size of games ValidateRules and ValidateInfo accept so States
+// created from untrusted info can't use too much memory.
+const (
+	// MaxBoardSize is the most Cells a board can have across or down.
+	MaxBoardSize = 100
+	// MaxPieceCount is the most Pieces each Player can have.
+	MaxPieceCount = 100
+)
+
+// validateSize returns an error if the Rules don't have Pieces and a board with
+// Cells or go past the limits on the size of games.
+func validateSize(r Rules) error {
+	if r.PieceCount() < 1 || r.BoardWidth() < 1 || r.BoardHeight() < 1 {
+		return errors.New(
+			"rules must have pieces and a board with cells",
+		)
+	}
+	if r.PieceCount() > MaxPieceCount {
+		return fmt.Errorf(
+			"rules can't have more than %d pieces",
+			MaxPieceCount,
+		)
+	}
+	if r.BoardWidth() > MaxBoardSize || r.BoardHeight() > MaxBoardSize {
+		return fmt.Errorf(
+			"rules can't have a board bigger than %dx%d",
+			MaxBoardSize, MaxBoardSize,
+		)
+	}
+	return nil
+}
+
+// ValidateRules returns an error if NewState can't start a valid game with the
+// Rules.
+//
+// The Rules must have Pieces and a board with Cells no bigger than
+// MaxPieceCount and MaxBoardSize allow. Rosters can't have Classes which allow
+// more than one Move in games with SimultaneousPlays, where each Piece only
+// makes one. A Layout must assign every Player a Cell for each of their Pieces.
+// Every Piece must start in an open Cell on the board which no other Piece
+// starts in. Errors about Players are PlayerErrors and errors about Pieces are
+// PieceErrors for the first invalid Piece in order of PieceID.
+func ValidateRules(r Rules) error {
+	if err := validateSize(r); err != nil {
+		return err
+	}
+	if r.PlayMode() == SimultaneousPlays {
+		for _, id := range r.PlayerIDs() {
+			for _, c := range r.Roster(id) {
+				if c.Moves() <= 1 {
+					continue
+				}
+				return &PlayerError{
+					Player: id,
+					Reason: fmt.Sprintf(
+						"has class %q with %d moves "+
+							"in simultaneous plays",
+						c.Name(), c.Moves(),
+					),
+				}
+			}
+		}
+	}
+	if l := r.Layout(); l != nil {
+		for _, id := range r.PlayerIDs() {
+			if n := len(l.Cells(id)); n < r.PieceCount() {
+				return &PlayerError{
+					Player: id,
+					Reason: fmt.Sprintf(
+						"has %d start cells, want %d",
+						n, r.PieceCount(),
+					),
+				}
+			}
+		}
+	}
+	seen := make(map[Cell]PieceID)
+	for _, id := range r.PlayerIDs() {
+		for i := 0; i < r.PieceCount(); i++ {
+			pid := PieceID((int(id)-1)*r.PieceCount() + i + 1)
+			c := startCell(r, id, i)
+			reason := ""
+			other, shared := seen[c]
+			switch {
+			case !r.IsOnBoard(c):
+				reason = "starts off the board"
+			case r.Terrain().IsBlocked(c):
+				reason = "starts in a blocked cell"
+			case shared:
+				reason = fmt.Sprintf(
+					"starts in the same cell as piece %d",
+					other,
+				)
+			}
+			if reason != "" {
+				return &PieceError{
+					Piece:  pid,
+					Cell:   c,
+					Reason: reason,
+				}
+			}
+			seen[c] = pid
+		}
+	}
+	return nil
+}
+
+// ValidateInfo returns an error if NewStateFromInfo can't create a valid State
+// from the info.
+//
+// The Rules must have Pieces and a board with Cells no bigger than
+// MaxPieceCount and MaxBoardSize allow. The current Player must be one of the
+// Rules' PlayerIDs unless there are no Pieces left, which makes NoPlayer
+// current. Every Piece must have a PieceID belonging to one of the Players and
+// not shared with another Piece, at least 1 life, no negative damage, and an
+// open Cell on the board. No Player can have more than the Rules' PieceCount
+// Pieces. Errors about Players are PlayerErrors and errors about Pieces are
+// PieceErrors for the first invalid Piece ordered by row and then column.
+func ValidateInfo(
+	r Rules,
+	currentPlayer PlayerID,
+	pieces map[Cell]Piece,
+) error {
+	if err := validateSize(r); err != nil {
+		return err
+	}
+	over := currentPlayer == NoPlayer && len(pieces) == 0
+	if !over && (currentPlayer <= NoPlayer ||
+		int(currentPlayer) > r.PlayerCount()) {
+		return &PlayerError{
+			Player: currentPlayer,
+			Reason: "isn't playing the game",
+		}
+	}
+	cs := make([]Cell, 0, len(pieces))
+	for c := range pieces {
+		cs = append(cs, c)
+	}
+	sortCells(cs)
+	seen := make(map[PieceID]Cell, len(pieces))
+	for _, c := range cs {
+		p := pieces[c]
+		reason := ""
+		other, shared := seen[p.ID()]
+		switch {
+		case p.ID() < 1 || int(p.ID()) > r.PlayerCount()*r.PieceCount():
+			reason = fmt.Sprintf(
+				"has an ID outside of 1 to %d",
+				r.PlayerCount()*r.PieceCount(),
+			)
+		case shared:
+			reason = fmt.Sprintf(
+				"has the same ID as the piece in cell %v",
+				other,
+			)
+		case p.Life() < 1:
+			reason = "has less than 1 life"
+		case p.Damage() < 0:
+			reason = "has negative damage"
+		case !r.IsOnBoard(c):
+			reason = "is off the board"
+		case r.Terrain().IsBlocked(c):
+			reason = "is in a blocked cell"
+		}
+		if reason != "" {
+			return &PieceError{
+				Piece:  p.ID(),
+				Cell:   c,
+				Reason: reason,
+			}
+		}
+		seen[p.ID()] = c
+	}
+	counts := make(map[PlayerID]int, r.PlayerCount())
+	for pid := range seen {
+		counts[PlayerID((int(pid)-1)/r.PieceCount()+1)]++
+	}
+	for _, id := range r.PlayerIDs() {
+		if counts[id] > r.PieceCount() {
+			return &PlayerError{
+				Player: id,
+				Reason: fmt.Sprintf(
+					"has %d pieces, more than %d",
+					counts[id], r.PieceCount(),
+				),
+			}
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the State isn't valid as described by
+// ValidateInfo.
+//
+// A PieceError is also returned if Pieces share a Cell.
+func Validate(s *State) error {
+	pieces := make(map[Cell]Piece)
+	for _, p := range s.Pieces() {
+		c := s.CellForPiece(p)
+		if other, ok := pieces[c]; ok {
+			return &PieceError{
+				Piece: p.ID(),
+				Cell:  c,
+				Reason: fmt.Sprintf(
+					"shares its cell with piece %d",
+					other.ID(),
+				),
+			}
+		}
+		pieces[c] = p
+	}
+	return ValidateInfo(s.Rules(), s.CurrentPlayer(), pieces)
+}
+
+// sortCells orders the Cells by row and then column.
+func sortCells(cs []Cell) {
+	sort.Slice(cs, func(i, j int) bool {
+		if cs[i].Row() != cs[j].Row() {
+			return cs[i].Row() < cs[j].Row()
+		}
+		return cs[i].Column() < cs[j].Column()
+	})
+}
@@ -0,0 +1,247 @@
+package game_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// TestValidateInfo tests that game.ValidateInfo returns game.PieceErrors and
+// game.PlayerErrors naming what is invalid.
+func TestValidateInfo(t *testing.T) {
+	t.Parallel()
+	r := game.NewRules(time.Second, 1, 1, 1, 1, 1).
+		WithTerrain(game.NewTerrain(game.NewCell(1, 1)))
+	at := game.NewCell
+	cases := []struct {
+		name    string
+		current game.PlayerID
+		pieces  map[game.Cell]game.Piece
+		player  game.PlayerID
+		piece   game.PieceID
+		cell    game.Cell
+		valid   bool
+	}{
+		{
+			name:    "valid",
+			current: game.Player2,
+			pieces: map[game.Cell]game.Piece{
+				at(0, 0): game.NewPiece(1, 1, 1),
+				at(2, 2): game.NewPiece(2, 1, 1),
+			},
+			valid: true,
+		},
+		{
+			name:    "over",
+			current: game.NoPlayer,
+			valid:   true,
+		},
+		{
+			name:    "no current player",
+			current: game.NoPlayer,
+			pieces: map[game.Cell]game.Piece{
+				at(0, 0): game.NewPiece(1, 1, 1),
+			},
+			player: game.NoPlayer,
+		},
+		{
+			name:    "current player not playing",
+			current: game.Player3,
+			player:  game.Player3,
+		},
+		{
+			name:    "id too large",
+			current: game.Player1,
+			pieces: map[game.Cell]game.Piece{
+				at(0, 0): game.NewPiece(1, 1, 1),
+				at(2, 2): game.NewPiece(3, 1, 1),
+			},
+			piece: 3,
+			cell:  at(2, 2),
+		},
+		{
+			name:    "shared id",
+			current: game.Player1,
+			pieces: map[game.Cell]game.Piece{
+				at(0, 0): game.NewPiece(1, 1, 1),
+				at(0, 1): game.NewPiece(1, 1, 1),
+			},
+			piece: 1,
+			cell:  at(0, 1),
+		},
+		{
+			name:    "no life",
+			current: game.Player1,
+			pieces: map[game.Cell]game.Piece{
+				at(0, 0): game.NewPiece(1, 0, 1),
+			},
+			piece: 1,
+			cell:  at(0, 0),
+		},
+		{
+			name:    "negative damage",
+			current: game.Player1,
+			pieces: map[game.Cell]game.Piece{
+				at(0, 0): game.NewPiece(1, 1, -1),
+			},
+			piece: 1,
+			cell:  at(0, 0),
+		},
+		{
+			name:    "off board",
+			current: game.Player1,
+			pieces: map[game.Cell]game.Piece{
+				at(0, 3): game.NewPiece(2, 1, 1),
+			},
+			piece: 2,
+			cell:  at(0, 3),
+		},
+		{
+			name:    "blocked",
+			current: game.Player1,
+			pieces: map[game.Cell]game.Piece{
+				at(1, 1): game.NewPiece(2, 1, 1),
+			},
+			piece: 2,
+			cell:  at(1, 1),
+		},
+	}
+	for _, c := range cases {
+		err := game.ValidateInfo(r, c.current, c.pieces)
+		var pe *game.PieceError
+		var ple *game.PlayerError
+		switch {
+		case c.piece != game.NoPieceID:
+			if !errors.As(err, &pe) || pe.Piece != c.piece ||
+				pe.Cell != c.cell {
+				t.Errorf(
+					"%s: game.ValidateInfo = %v, "+
+						"want piece %d in cell %v",
+					c.name, err, c.piece, c.cell,
+				)
+			}
+		case c.valid:
+			if err != nil {
+				t.Errorf(
+					"%s: game.ValidateInfo = %v, want %v",
+					c.name, err, nil,
+				)
+			}
+		default:
+			if !errors.As(err, &ple) || ple.Player != c.player {
+				t.Errorf(
+					"%s: game.ValidateInfo = %v, want %v",
+					c.name, err, c.player,
+				)
+			}
+		}
+	}
+	huge := game.StandardRules.WithBoardSize(30000, 30000)
+	if err := game.ValidateInfo(huge, game.Player1, nil); err == nil {
+		t.Errorf("game.ValidateInfo(huge, ...) = %v, want error", err)
+	}
+}
+
+// TestValidate tests that game.Validate accepts game.States created by the
+// package.
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	s := game.NewState(game.StandardRules, nil, nil)
+	for i := 0; i < 5 && !s.IsOver(); i++ {
+		if err := game.Validate(s); err != nil {
+			t.Errorf(
+				"turn %d: game.Validate(s) = %v",
+				s.Turn(), err,
+			)
+		}
+		s = game.NextStateWithPlay(s, game.LegalPlays(s)[0])
+	}
+}
+
+// TestValidateRules tests that game.ValidateRules rejects game.Rules where
+// game.Pieces don't fit in their start game.Cells.
+func TestValidateRules(t *testing.T) {
+	t.Parallel()
+	at := game.NewCell
+	r := game.NewRules(time.Second, 3, 1, 1, 1, 1)
+	cases := []struct {
+		name  string
+		rules game.Rules
+		piece game.PieceID
+		cell  game.Cell
+		valid bool
+	}{
+		{name: "standard", rules: game.StandardRules, valid: true},
+		{
+			name:  "four players",
+			rules: r.WithPlayerCount(4),
+			valid: true,
+		},
+		{
+			name:  "no pieces",
+			rules: game.NewRules(time.Second, 0, 1, 1, 1, 1),
+		},
+		{
+			name:  "narrow",
+			rules: r.WithBoardSize(2, 3),
+			piece: 6,
+			cell:  at(1, 1),
+		},
+		{
+			name:  "too many pieces",
+			rules: game.NewRules(time.Second, 101, 1, 1, 1, 1),
+		},
+		{
+			name:  "too big",
+			rules: game.StandardRules.WithBoardSize(5000, 5000),
+		},
+		{
+			name: "simultaneous tanks",
+			rules: game.StandardRules.
+				WithPlayMode(game.SimultaneousPlays).
+				WithRoster(game.Player1, game.Tank),
+			valid: true,
+		},
+		{
+			name: "simultaneous scouts",
+			rules: game.StandardRules.
+				WithPlayMode(game.SimultaneousPlays).
+				WithRoster(game.Player2, game.Tank, game.Scout),
+		},
+		{
+			name:  "crowded",
+			rules: r.WithBoardSize(3, 3).WithPlayerCount(4),
+			piece: 7,
+			cell:  at(0, 0),
+		},
+	}
+	for _, c := range cases {
+		err := game.ValidateRules(c.rules)
+		var pe *game.PieceError
+		switch {
+		case c.valid:
+			if err != nil {
+				t.Errorf(
+					"%s: game.ValidateRules = %v, want %v",
+					c.name, err, nil,
+				)
+			}
+		case c.piece != game.NoPieceID:
+			if !errors.As(err, &pe) || pe.Piece != c.piece ||
+				pe.Cell != c.cell {
+				t.Errorf(
+					"%s: game.ValidateRules = %v, "+
+						"want piece %d in cell %v",
+					c.name, err, c.piece, c.cell,
+				)
+			}
+		case err == nil:
+			t.Errorf(
+				"%s: game.ValidateRules = %v, want error",
+				c.name, err,
+			)
+		}
+	}
+}
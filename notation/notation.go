@@ -0,0 +1,7 @@
+// Package notation writes and reads landgrab Plays, Rules, and complete games
+// as compact text.
+//
+// Plays are written as Moves separated by spaces such as "3:NE 5:S". Records
+// of complete games have header tags for the Players, Rules, result, and date
+// followed by numbered turns, and replay exactly through the game package.
+package notation
@@ -0,0 +1,124 @@
+package notation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// EmptyPlay is the notation of a Play without Moves.
+const EmptyPlay = "-"
+
+// directions by their abbreviations.
+var directions = map[string]game.Direction{
+	"N":  game.North,
+	"NE": game.NorthEast,
+	"E":  game.East,
+	"SE": game.SouthEast,
+	"S":  game.South,
+	"SW": game.SouthWest,
+	"W":  game.West,
+	"NW": game.NorthWest,
+}
+
+// abbreviation of the Direction or an empty string if it has none.
+func abbreviation(d game.Direction) string {
+	for abbr, x := range directions {
+		if x == d {
+			return abbr
+		}
+	}
+	return ""
+}
+
+// FormatMove returns the notation of the Move.
+//
+// Moves are written as the PieceID of the Piece making the Move and the
+// abbreviation of its Direction separated by a colon such as "3:NE". Moves
+// which aren't 1 Cell in a Direction have their Offset in rows and columns
+// instead such as "3:-2,1".
+func FormatMove(m game.Move) string {
+	id := int(m.Piece().ID())
+	if d := m.Direction(); d != game.NoDirection {
+		return fmt.Sprintf("%d:%s", id, abbreviation(d))
+	}
+	o := m.Offset()
+	return fmt.Sprintf("%d:%d,%d", id, o.Rows(), o.Columns())
+}
+
+// ParseMove from its notation in the State.
+//
+// The Piece making the Move is the one in the State with the PieceID. An error
+// is returned if the notation is invalid or the State has no such Piece.
+func ParseMove(s *game.State, text string) (game.Move, error) {
+	id, to, ok := strings.Cut(text, ":")
+	if !ok {
+		return game.NoMove, fmt.Errorf("move %q has no colon", text)
+	}
+	pid, err := strconv.Atoi(id)
+	if err != nil {
+		return game.NoMove, fmt.Errorf("invalid piece in move %q", text)
+	}
+	p, ok := statePiece(s, game.PieceID(pid))
+	if !ok {
+		return game.NoMove, fmt.Errorf("no piece in move %q", text)
+	}
+	if d, ok := directions[to]; ok {
+		return game.NewMove(p, d), nil
+	}
+	rows, columns, ok := strings.Cut(to, ",")
+	r, rerr := strconv.Atoi(rows)
+	c, cerr := strconv.Atoi(columns)
+	if !ok || rerr != nil || cerr != nil {
+		return game.NoMove, fmt.Errorf("invalid offset in %q", text)
+	}
+	return game.NewOffsetMove(p, game.NewOffset(r, c)), nil
+}
+
+// statePiece returns the Piece in the State with the PieceID and true if there
+// is one.
+func statePiece(s *game.State, pid game.PieceID) (game.Piece, bool) {
+	for _, p := range s.Pieces() {
+		if p.ID() == pid {
+			return p, true
+		}
+	}
+	return game.NoPiece, false
+}
+
+// FormatPlay returns the notation of the Play.
+//
+// Plays are written as their Moves in order separated by spaces. Plays without
+// Moves are written as EmptyPlay.
+func FormatPlay(p game.Play) string {
+	if len(p) == 0 {
+		return EmptyPlay
+	}
+	ms := make([]string, len(p))
+	for i, m := range p {
+		ms[i] = FormatMove(m)
+	}
+	return strings.Join(ms, " ")
+}
+
+// ParsePlay from its notation in the State.
+//
+// An error is returned if any of the Moves is invalid as described in
+// ParseMove. The Play isn't checked against the Rules.
+func ParsePlay(s *game.State, text string) (game.Play, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 1 && fields[0] == EmptyPlay {
+		return game.Play{}, nil
+	}
+	p := make(game.Play, len(fields))
+	for i, field := range fields {
+		m, err := ParseMove(s, field)
+		if err != nil {
+			return nil, err
+		}
+		p[i] = m
+	}
+	return p, nil
+}
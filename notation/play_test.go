@@ -0,0 +1,76 @@
+package notation_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jwowillo/landgrab/game"
+	"github.com/jwowillo/landgrab/notation"
+)
+
+// TestPlay tests that game.Plays are formatted with notation.FormatPlay and
+// parsed back to the same game.Plays with notation.ParsePlay.
+func TestPlay(t *testing.T) {
+	t.Parallel()
+	s := game.NewState(game.StandardRules, nil, nil)
+	p3, p8 := s.Pieces()[2], s.Pieces()[7]
+	cases := []struct {
+		play game.Play
+		text string
+	}{
+		{play: game.Play{}, text: "-"},
+		{
+			play: game.Play{
+				game.NewMove(p3, game.NorthEast),
+				game.NewMove(p8, game.South),
+			},
+			text: "3:NE 8:S",
+		},
+		{
+			play: game.Play{
+				game.NewMove(p3, game.West),
+				game.NewMove(p3, game.NorthWest),
+			},
+			text: "3:W 3:NW",
+		},
+		{
+			play: game.Play{
+				game.NewOffsetMove(p8, game.NewOffset(-2, 1)),
+			},
+			text: "8:-2,1",
+		},
+	}
+	for _, c := range cases {
+		if got := notation.FormatPlay(c.play); got != c.text {
+			t.Errorf(
+				"notation.FormatPlay(%v) = %q, want %q",
+				c.play, got, c.text,
+			)
+		}
+		p, err := notation.ParsePlay(s, c.text)
+		if err != nil || !reflect.DeepEqual(p, c.play) {
+			t.Errorf(
+				"notation.ParsePlay(s, %q) = %v, %v, "+
+					"want %v, %v",
+				c.text, p, err, c.play, nil,
+			)
+		}
+	}
+}
+
+// TestParsePlayErrors tests that notation.ParsePlay rejects invalid
+// notation and game.Pieces which aren't in the game.State.
+func TestParsePlayErrors(t *testing.T) {
+	t.Parallel()
+	s := game.NewState(game.StandardRules, nil, nil)
+	for _, text := range []string{
+		"3", "x:N", "3:UP", "3:1", "3:1,x", "11:N", "0:N", "3:N -",
+	} {
+		if p, err := notation.ParsePlay(s, text); err == nil {
+			t.Errorf(
+				"notation.ParsePlay(s, %q) = %v, want error",
+				text, p,
+			)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package notation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// deploying is the suffix of the side to move during the deployment phase.
+const deploying = "@"
+
+// FormatPosition returns the position of the State on one line.
+//
+// Positions have 4 fields separated by spaces:
+//
+//	board  rows separated by slashes from the top where each run of empty
+//	       Cells is its length and each Piece is [id:life:damage]
+//	side   number of the Player to move, suffixed with @ during the
+//	       deployment phase, or - if nobody is left to move
+//	turn   number of the turn
+//	rules  reference FormatRules returns
+//
+// Games with territory control have a fifth field of the owner of each Cell as
+// a map in the form accepted by game.ParseLayout with rows separated by
+// slashes.
+//
+// The standard starting position with 2 Pieces per Player is:
+//
+//	1[1:3:1]1[2:3:1]1/5/5/5/1[3:3:1]1[4:3:1]1 1 0 pieces=2
+//
+// Blocked Cells are counted as empty since they're in the Rules. Positions
+// don't have the Players, earlier positions, or Pieces hidden by fog of war.
+// An error is returned if the Rules can't be formatted.
+func FormatPosition(s *game.State) (string, error) {
+	ref, err := FormatRules(s.Rules())
+	if err != nil {
+		return "", err
+	}
+	r := s.Rules()
+	rows := make([]string, r.BoardHeight())
+	for i := range rows {
+		var b strings.Builder
+		empty := 0
+		for j := 0; j < r.BoardWidth(); j++ {
+			p := s.PieceForCell(game.NewCell(i, j))
+			if p == game.NoPiece {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				b.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			fmt.Fprintf(
+				&b, "[%d:%d:%d]",
+				p.ID(), p.Life(), p.Damage(),
+			)
+		}
+		if empty > 0 {
+			b.WriteString(strconv.Itoa(empty))
+		}
+		rows[i] = b.String()
+	}
+	side := "-"
+	if s.CurrentPlayer() != game.NoPlayer {
+		side = strconv.Itoa(int(s.CurrentPlayer()))
+	}
+	if s.IsDeploying() {
+		side += deploying
+	}
+	position := fmt.Sprintf(
+		"%s %s %d %s",
+		strings.Join(rows, "/"), side, s.Turn(), ref,
+	)
+	if r.TerritoryControl() > 0 {
+		position += " " + formatMap(ownersMap(s))
+	}
+	return position, nil
+}
+
+// ownersMap returns the map of the owner of each Cell in the State.
+func ownersMap(s *game.State) []string {
+	r := s.Rules()
+	rows := make([]string, r.BoardHeight())
+	for i := range rows {
+		row := make([]byte, r.BoardWidth())
+		for j := range row {
+			row[j] = game.OpenSymbol
+			id := s.Owner(game.NewCell(i, j))
+			if id != game.NoPlayer {
+				row[j] = byte('0' + id)
+			}
+		}
+		rows[i] = string(row)
+	}
+	return rows
+}
+
+// ParsePosition returns the State with the position FormatPosition returns.
+//
+// The State is played by nil Players. Pieces have the Classes the Rules'
+// rosters give their PieceIDs. An error is returned if the position is
+// malformed or ParseRules or game.ValidateInfo rejects it. The Rules are
+// checked before the board is parsed.
+func ParsePosition(text string) (*game.State, error) {
+	return ParsePositionWithPlayers(text, nil)
+}
+
+// ParsePositionWithPlayers is ParsePosition for a State played by the Players
+// in order.
+func ParsePositionWithPlayers(
+	text string,
+	ps []game.Player,
+) (*game.State, error) {
+	r, err := positionRules(text)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(text)
+	want := 4
+	if r.TerritoryControl() > 0 {
+		want = 5
+	}
+	if len(fields) != want {
+		return nil, fmt.Errorf(
+			"position %q needs %d fields",
+			text, want,
+		)
+	}
+	pieces, err := parseBoard(r, fields[0])
+	if err != nil {
+		return nil, err
+	}
+	side, deploy := strings.CutSuffix(fields[1], deploying)
+	current := game.NoPlayer
+	if side != "-" {
+		id, err := strconv.Atoi(side)
+		if err != nil {
+			return nil, fmt.Errorf("invalid side %q", fields[1])
+		}
+		current = game.PlayerID(id)
+	}
+	turn, err := strconv.Atoi(fields[2])
+	if err != nil || turn < 0 {
+		return nil, fmt.Errorf("invalid turn %q", fields[2])
+	}
+	if err := game.ValidateInfo(r, current, pieces); err != nil {
+		return nil, err
+	}
+	if n := r.PlayerCount() - len(ps); n > 0 {
+		ps = append(ps, make([]game.Player, n)...)
+	}
+	s := game.NewStateFromInfoWithPlayers(r, current, ps, pieces)
+	s = s.WithTurn(turn).WithDeploying(deploy)
+	if r.TerritoryControl() > 0 {
+		owners, err := game.ParseLayout(parseMap(fields[4]))
+		if err != nil {
+			return nil, err
+		}
+		s = s.WithOwners(layoutOwners(owners))
+	}
+	return s, nil
+}
+
+// positionRules returns the Rules of the position FormatPosition returns
+// without parsing the rest of it.
+//
+// An error is returned if the position has too few fields or ParseRules rejects
+// its Rules.
+func positionRules(text string) (game.Rules, error) {
+	fields := strings.Fields(text)
+	if len(fields) < 4 {
+		return game.StandardRules, fmt.Errorf(
+			"position %q needs 4 fields",
+			text,
+		)
+	}
+	return ParseRules(fields[3])
+}
+
+// layoutOwners returns the owner of each Cell in the game.Layout.
+func layoutOwners(l *game.Layout) map[game.Cell]game.PlayerID {
+	owners := make(map[game.Cell]game.PlayerID)
+	for id := game.Player1; int(id) <= game.MaxPlayerCount; id++ {
+		for _, c := range l.Cells(id) {
+			owners[c] = id
+		}
+	}
+	return owners
+}
+
+// parseBoard returns the Pieces in each Cell of the board of a position with
+// the Rules.
+func parseBoard(
+	r game.Rules,
+	board string,
+) (map[game.Cell]game.Piece, error) {
+	rows := strings.Split(board, "/")
+	if len(rows) != r.BoardHeight() {
+		return nil, fmt.Errorf(
+			"board has %d rows, want %d",
+			len(rows), r.BoardHeight(),
+		)
+	}
+	pieces := make(map[game.Cell]game.Piece)
+	for i, row := range rows {
+		j := 0
+		for row != "" {
+			if row[0] == '[' {
+				end := strings.IndexByte(row, ']')
+				if end < 0 {
+					return nil, fmt.Errorf(
+						"unclosed piece in %q",
+						row,
+					)
+				}
+				p, err := parsePiece(r, row[1:end])
+				if err != nil {
+					return nil, err
+				}
+				pieces[game.NewCell(i, j)] = p
+				j++
+				row = row[end+1:]
+				continue
+			}
+			n := strings.IndexByte(row, '[')
+			if n < 0 {
+				n = len(row)
+			}
+			empty, err := strconv.Atoi(row[:n])
+			if err != nil || empty < 1 {
+				return nil, fmt.Errorf(
+					"invalid run %q",
+					row[:n],
+				)
+			}
+			j += empty
+			row = row[n:]
+		}
+		if j != r.BoardWidth() {
+			return nil, fmt.Errorf(
+				"row %d has %d cells, want %d",
+				i, j, r.BoardWidth(),
+			)
+		}
+	}
+	return pieces, nil
+}
+
+// parsePiece from its id:life:damage form in a position with the Rules.
+func parsePiece(r game.Rules, text string) (game.Piece, error) {
+	invalid := fmt.Errorf("invalid piece %q", text)
+	fields := strings.Split(text, ":")
+	var xs [3]int
+	if len(fields) != len(xs) {
+		return game.NoPiece, invalid
+	}
+	for i, field := range fields {
+		x, err := strconv.Atoi(field)
+		if err != nil {
+			return game.NoPiece, invalid
+		}
+		xs[i] = x
+	}
+	pid := game.PieceID(xs[0])
+	c := game.StandardClass
+	if pid > game.NoPieceID {
+		i := int(pid) - 1
+		id := game.PlayerID(i/r.PieceCount() + 1)
+		if roster := r.Roster(id); i%r.PieceCount() < len(roster) {
+			c = roster[i%r.PieceCount()]
+		}
+	}
+	return game.NewPieceWithClass(pid, xs[1], xs[2], c), nil
+}
@@ -0,0 +1,136 @@
+package notation_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+	"github.com/jwowillo/landgrab/notation"
+)
+
+// TestPositionExample tests that the standard starting game.State with 2
+// game.Pieces per game.Player has the documented position.
+func TestPositionExample(t *testing.T) {
+	t.Parallel()
+	r := game.StandardRules
+	r = game.NewRules(
+		r.TimerDuration(), 2, r.Life(), r.Damage(),
+		r.LifeIncrease(), r.DamageIncrease(),
+	)
+	want := "1[1:3:1]1[2:3:1]1/5/5/5/1[3:3:1]1[4:3:1]1 " +
+		"1 0 pieces=2"
+	got, err := notation.FormatPosition(game.NewState(r, nil, nil))
+	if err != nil || got != want {
+		t.Errorf(
+			"notation.FormatPosition(s) = %q, %v, want %q, %v",
+			got, err, want, nil,
+		)
+	}
+}
+
+// TestPosition tests that the game.States of random games are formatted with
+// notation.FormatPosition and parsed back to the same game.States with
+// notation.ParsePosition.
+func TestPosition(t *testing.T) {
+	t.Parallel()
+	small := game.NewRules(time.Second, 3, 2, 1, 1, 1).WithBoardSize(5, 4)
+	cases := []struct {
+		name  string
+		rules game.Rules
+	}{
+		{name: "standard", rules: game.StandardRules},
+		{
+			name: "rosters",
+			rules: small.
+				WithRoster(game.Player1, game.Scout).
+				WithRoster(
+					game.Player2,
+					game.Tank, game.Striker,
+				).
+				WithTerrain(
+					game.NewTerrain(game.NewCell(2, 2)),
+				),
+		},
+		{
+			name:  "three players",
+			rules: small.WithPlayerCount(3).WithBoardSize(7, 7),
+		},
+		{
+			name:  "deployment",
+			rules: small.WithHomeZones(game.SideZones(small, 1)),
+		},
+		{
+			name:  "territory",
+			rules: small.WithTerritoryControl(40),
+		},
+	}
+	for i, c := range cases {
+		rng := rand.New(rand.NewSource(int64(i)))
+		s := notation.Record{Rules: c.rules}.Start()
+		for turn := 0; turn < 40 && !s.IsOver(); turn++ {
+			name := fmt.Sprintf("%s: turn %d", c.name, turn)
+			checkPosition(t, name, s)
+			s, _ = randomTurn(s, rng)
+		}
+		checkPosition(t, c.name+": end", s)
+	}
+}
+
+// checkPosition checks that the game.State round-trips through its position.
+func checkPosition(t *testing.T, name string, s *game.State) {
+	text, err := notation.FormatPosition(s)
+	if err != nil {
+		t.Fatalf("%s: notation.FormatPosition(s) error = %v", name, err)
+	}
+	got, err := notation.ParsePosition(text)
+	if err != nil {
+		t.Fatalf(
+			"%s: notation.ParsePosition(%q) error = %v",
+			name, text, err,
+		)
+	}
+	if got.Hash() != s.Hash() || got.Turn() != s.Turn() ||
+		got.IsDeploying() != s.IsDeploying() ||
+		fmt.Sprint(got.Pieces()) != fmt.Sprint(s.Pieces()) ||
+		fmt.Sprint(got.Eliminated()) != fmt.Sprint(s.Eliminated()) {
+		t.Errorf("%s: notation.ParsePosition(%q) differs", name, text)
+	}
+	if again, _ := notation.FormatPosition(got); again != text {
+		t.Errorf("%s: %q formats as %q", name, text, again)
+	}
+}
+
+// TestParsePositionErrors tests that notation.ParsePosition rejects malformed
+// positions and ones game.ValidateRules or game.ValidateInfo rejects.
+func TestParsePositionErrors(t *testing.T) {
+	t.Parallel()
+	for _, text := range []string{
+		"5/5/5/5/5 1 0",
+		"5/5/5/5/5 1 0 pieces=x",
+		"5/5/5/5 1 0 pieces=2",
+		"5/5/5/5/4 1 0 pieces=2",
+		"5/5/5/5/6 1 0 pieces=2",
+		"[1:3:1]4/5/5/5/0[3:3:1]4 1 0 pieces=2",
+		"[1:3:1]4/5/5/5/[3:3]4 1 0 pieces=2",
+		"[1:3:14/5/5/5/5 1 0 pieces=2",
+		"[1:3:1]4/5/5/5/[3:3:1]4 x 0 pieces=2",
+		"[1:3:1]4/5/5/5/[3:3:1]4 1 -1 pieces=2",
+		"[1:3:1]4/5/5/5/[9:3:1]4 1 0 pieces=2",
+		"[1:0:1]4/5/5/5/[3:3:1]4 1 0 pieces=2",
+		"[1:3:1]4/5/5/5/[3:3:1]4 3 0 pieces=2",
+		"[1:3:1]4/5/5/5/[3:3:1]4 1 0 pieces=2;territory=50",
+		"[1:3:1]4/5/5/5/[3:3:1]4 1 0 pieces=2;territory=50 1----",
+		"1[1:3:1] 1 0 pieces=0;size=2x1",
+		"1000[1:3:1] 1 0 pieces=1;size=1001x1",
+		"[1:3:1]4/5/5/5/[3:3:1]4 1 0 pieces=1000;size=5x5",
+	} {
+		if s, err := notation.ParsePosition(text); err == nil {
+			t.Errorf(
+				"notation.ParsePosition(%q) = %v, want error",
+				text, s,
+			)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package notation
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// Results of games in Records besides the number of the winning Player.
+const (
+	// Unfinished is the result of a game which isn't over.
+	Unfinished = "*"
+	// Draw is the result of a game which ended without a winner.
+	Draw = "draw"
+)
+
+// Record of a game.
+//
+// Records are written as header tags of the form [Key "value"] followed by a
+// blank line and a numbered line for each Turn such as:
+//
+//	[Date "2017.06.01"]
+//	[Player1 "greedy"]
+//	[Player2 "random"]
+//	[Rules "standard"]
+//	[Result "1"]
+//
+//	1. 3:S
+//	2. 8:N 8:NE
+//	3. -
+//
+// The Rules tag holds the reference FormatRules returns and the Result tag
+// holds the result Result returns.
+type Record struct {
+	// Date the game was played on in the form YYYY.MM.DD.
+	Date string
+	// Players who played the game in order of PlayerID.
+	Players []string
+	// Rules the game was played with.
+	Rules game.Rules
+	// Result of the game.
+	Result string
+	// Turns of the game in order.
+	Turns []Turn
+}
+
+// Turn in a Record.
+//
+// Turns during the deployment phase have the Cells the current Player deployed
+// to and are written as the Cells prefixed with @ such as "@0,1 @0,3". All
+// other Turns have the Play made. Turns in games with SimultaneousPlays have
+// every Player's Moves in one Play.
+type Turn struct {
+	Play  game.Play
+	Cells []game.Cell
+}
+
+// Result of the game at the State.
+//
+// The result is the number of the winning Player such as "1", Draw, or
+// Unfinished if the game isn't over.
+func Result(s *game.State) string {
+	switch {
+	case s.Winner() != game.NoPlayer:
+		return strconv.Itoa(int(s.Winner()))
+	case s.IsDraw():
+		return Draw
+	default:
+		return Unfinished
+	}
+}
+
+// Start returns the State the game in the Record starts at.
+func (r Record) Start() *game.State {
+	ps := make([]game.Player, r.Rules.PlayerCount())
+	return game.NewStateWithPlayers(r.Rules, ps)
+}
+
+// Replay the game in the Record and return every State it goes through from
+// Start.
+//
+// Plays are made with game.NextStateWithPlay, or game.NextStateWithPlays in
+// games with SimultaneousPlays, and deployments with game.Deploy. An error is
+// returned if a Turn comes after the game is over or a deployment is invalid.
+func (r Record) Replay() ([]*game.State, error) {
+	states := []*game.State{r.Start()}
+	for i, t := range r.Turns {
+		s, err := nextState(states[len(states)-1], t)
+		if err != nil {
+			return states, fmt.Errorf("turn %d: %w", i+1, err)
+		}
+		states = append(states, s)
+	}
+	return states, nil
+}
+
+// nextState returns the State after the Turn is made from the State.
+func nextState(s *game.State, t Turn) (*game.State, error) {
+	switch {
+	case s.IsOver():
+		return s, fmt.Errorf("game is already over")
+	case s.IsDeploying():
+		return game.Deploy(s, t.Cells)
+	case t.Cells != nil:
+		return s, fmt.Errorf("game isn't in its deployment phase")
+	case s.Rules().PlayMode() == game.SimultaneousPlays:
+		ps := make(map[game.PlayerID]game.Play)
+		for _, m := range t.Play {
+			id := s.PlayerForPiece(m.Piece())
+			ps[id] = append(ps[id], m)
+		}
+		return game.NextStateWithPlays(s, ps), nil
+	default:
+		return game.NextStateWithPlay(s, t.Play), nil
+	}
+}
+
+// WriteRecord to the io.Writer.
+//
+// An error is returned if the Rules can't be formatted or writing fails.
+// Records without a Result are written as Unfinished.
+func WriteRecord(w io.Writer, r Record) error {
+	ref, err := FormatRules(r.Rules)
+	if err != nil {
+		return err
+	}
+	result := r.Result
+	if result == "" {
+		result = Unfinished
+	}
+	b := bufio.NewWriter(w)
+	tag := func(key, v string) {
+		fmt.Fprintf(b, "[%s %s]\n", key, strconv.Quote(v))
+	}
+	if r.Date != "" {
+		tag("Date", r.Date)
+	}
+	for i, p := range r.Players {
+		tag(fmt.Sprintf("Player%d", i+1), p)
+	}
+	tag("Rules", ref)
+	tag("Result", result)
+	fmt.Fprintln(b)
+	for i, t := range r.Turns {
+		fmt.Fprintf(b, "%d. %s\n", i+1, formatTurn(t))
+	}
+	return b.Flush()
+}
+
+// formatTurn returns the notation of the Turn.
+func formatTurn(t Turn) string {
+	if t.Cells == nil {
+		return FormatPlay(t.Play)
+	}
+	cs := make([]string, len(t.Cells))
+	for i, c := range t.Cells {
+		cs[i] = fmt.Sprintf("@%d,%d", c.Row(), c.Column())
+	}
+	return strings.Join(cs, " ")
+}
+
+// tagPattern matches header tags and captures their key and quoted value.
+var tagPattern = regexp.MustCompile(`^\[(\w+) (".*")\]$`)
+
+// ReadRecord from the io.Reader.
+//
+// Unknown tags are ignored. The game is replayed as it's read since Moves
+// refer to the Pieces in the State they're made from. An error is returned if
+// the Record is malformed, has Rules ParseRules rejects, doesn't replay as
+// described in Record.Replay, or has a Result which isn't the result of the
+// replayed game.
+func ReadRecord(rd io.Reader) (Record, error) {
+	r := Record{Rules: game.StandardRules, Result: Unfinished}
+	var s *game.State
+	scanner := bufio.NewScanner(rd)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var err error
+		if s == nil && strings.HasPrefix(line, "[") {
+			err = readTag(&r, line)
+		} else {
+			if s == nil {
+				s = r.Start()
+			}
+			s, err = readTurn(&r, s, line)
+		}
+		if err != nil {
+			return r, fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return r, err
+	}
+	if s == nil {
+		s = r.Start()
+	}
+	if got := Result(s); r.Result != Unfinished && r.Result != got {
+		return r, fmt.Errorf(
+			"result %q doesn't match replayed result %q",
+			r.Result, got,
+		)
+	}
+	return r, nil
+}
+
+// readTag from the line into the Record.
+func readTag(r *Record, line string) error {
+	m := tagPattern.FindStringSubmatch(line)
+	if m == nil {
+		return fmt.Errorf("invalid tag %q", line)
+	}
+	v, err := strconv.Unquote(m[2])
+	if err != nil {
+		return fmt.Errorf("invalid tag value %s", m[2])
+	}
+	key := m[1]
+	switch {
+	case key == "Date":
+		r.Date = v
+	case key == "Rules":
+		r.Rules, err = ParseRules(v)
+	case key == "Result":
+		r.Result = v
+	case strings.HasPrefix(key, "Player"):
+		i, aerr := strconv.Atoi(strings.TrimPrefix(key, "Player"))
+		if aerr != nil || i < 1 || i > game.MaxPlayerCount {
+			return nil
+		}
+		for len(r.Players) < i {
+			r.Players = append(r.Players, "")
+		}
+		r.Players[i-1] = v
+	}
+	return err
+}
+
+// readTurn from the line into the Record and return the State after it's made
+// from the State.
+func readTurn(r *Record, s *game.State, line string) (*game.State, error) {
+	number, text, _ := strings.Cut(line, " ")
+	if want := fmt.Sprintf("%d.", len(r.Turns)+1); number != want {
+		return s, fmt.Errorf("turn %q isn't numbered %s", line, want)
+	}
+	var t Turn
+	if s.IsDeploying() {
+		cells, err := parseCells(text)
+		if err != nil {
+			return s, err
+		}
+		t.Cells = cells
+	} else {
+		p, err := ParsePlay(s, text)
+		if err != nil {
+			return s, err
+		}
+		t.Play = p
+	}
+	s, err := nextState(s, t)
+	if err != nil {
+		return s, err
+	}
+	r.Turns = append(r.Turns, t)
+	return s, nil
+}
+
+// parseCells from the notation of a deployment.
+func parseCells(text string) ([]game.Cell, error) {
+	cells := []game.Cell{}
+	for _, field := range strings.Fields(text) {
+		var r, c int
+		_, err := fmt.Sscanf(field, "@%d,%d", &r, &c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cell %q", field)
+		}
+		cells = append(cells, game.NewCell(r, c))
+	}
+	return cells, nil
+}
@@ -0,0 +1,203 @@
+package notation_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+	"github.com/jwowillo/landgrab/notation"
+)
+
+// TestRecordExample tests that a notation.Record is written in the documented
+// form and read back.
+func TestRecordExample(t *testing.T) {
+	t.Parallel()
+	r := notation.Record{
+		Date:    "2017.06.01",
+		Players: []string{"greedy", "random"},
+		Rules:   game.StandardRules,
+	}
+	s := r.Start()
+	for _, text := range []string{"3:S", "8:N", "-"} {
+		p, err := notation.ParsePlay(s, text)
+		if err != nil {
+			t.Fatalf(
+				"notation.ParsePlay(s, %q) error = %v",
+				text, err,
+			)
+		}
+		r.Turns = append(r.Turns, notation.Turn{Play: p})
+		s = game.NextStateWithPlay(s, p)
+	}
+	want := `[Date "2017.06.01"]
+[Player1 "greedy"]
+[Player2 "random"]
+[Rules "standard"]
+[Result "*"]
+
+1. 3:S
+2. 8:N
+3. -
+`
+	var b bytes.Buffer
+	err := notation.WriteRecord(&b, r)
+	if err != nil || b.String() != want {
+		t.Errorf(
+			"notation.WriteRecord(w, r) wrote %q, %v, want %q, %v",
+			b.String(), err, want, nil,
+		)
+	}
+	read, err := notation.ReadRecord(strings.NewReader(want))
+	if err != nil {
+		t.Fatalf("notation.ReadRecord(r) error = %v", err)
+	}
+	if read.Date != r.Date || len(read.Turns) != len(r.Turns) ||
+		fmt.Sprint(read.Players) != fmt.Sprint(r.Players) {
+		t.Errorf("notation.ReadRecord(r) = %v, want %v", read, r)
+	}
+}
+
+// TestRecordReplay tests that random games written as notation.Records and
+// read back replay through the same game.States.
+func TestRecordReplay(t *testing.T) {
+	t.Parallel()
+	small := game.NewRules(time.Second, 3, 2, 1, 1, 1).WithBoardSize(4, 4)
+	cases := []struct {
+		name  string
+		rules game.Rules
+	}{
+		{name: "standard", rules: game.StandardRules},
+		{name: "small", rules: small},
+		{
+			name: "rosters",
+			rules: small.
+				WithRoster(game.Player1, game.Scout).
+				WithRoster(
+					game.Player2,
+					game.Tank, game.Striker,
+				).
+				WithPattern(game.SlidePattern),
+		},
+		{
+			name: "three players",
+			rules: small.WithPlayerCount(3).WithBoardSize(7, 7).
+				WithMaxTurns(40),
+		},
+		{
+			name:  "simultaneous",
+			rules: small.WithPlayMode(game.SimultaneousPlays),
+		},
+		{
+			name:  "deployment",
+			rules: small.WithHomeZones(game.SideZones(small, 1)),
+		},
+	}
+	for i, c := range cases {
+		rng := rand.New(rand.NewSource(int64(i)))
+		for g := 0; g < 3; g++ {
+			name := fmt.Sprintf("%s: game %d", c.name, g)
+			checkReplay(t, name, c.rules, rng)
+		}
+	}
+}
+
+// checkReplay plays a random game of up to 60 turns with the game.Rules, writes
+// it as a notation.Record, reads it back, and checks that both replay through
+// the game.States of the game.
+func checkReplay(t *testing.T, name string, rules game.Rules, rng *rand.Rand) {
+	r := notation.Record{Rules: rules}
+	s := r.Start()
+	states := []*game.State{s}
+	for turn := 0; turn < 60 && !s.IsOver(); turn++ {
+		var tn notation.Turn
+		s, tn = randomTurn(s, rng)
+		states = append(states, s)
+		r.Turns = append(r.Turns, tn)
+	}
+	r.Result = notation.Result(s)
+	var b bytes.Buffer
+	if err := notation.WriteRecord(&b, r); err != nil {
+		t.Fatalf("%s: notation.WriteRecord(w, r) error = %v", name, err)
+	}
+	read, err := notation.ReadRecord(&b)
+	if err != nil {
+		t.Fatalf("%s: notation.ReadRecord(r) error = %v", name, err)
+	}
+	for _, rec := range []notation.Record{r, read} {
+		replayed, err := rec.Replay()
+		if err != nil || len(replayed) != len(states) {
+			t.Fatalf(
+				"%s: replayed %d game.States, %v, want %d, %v",
+				name, len(replayed), err, len(states), nil,
+			)
+		}
+		for i := range states {
+			if replayed[i].Hash() != states[i].Hash() ||
+				replayed[i].Turn() != states[i].Turn() {
+				t.Fatalf("%s: turn %d doesn't replay", name, i)
+			}
+		}
+	}
+}
+
+// randomTurn makes a random notation.Turn from the game.State and returns the
+// next game.State along with it.
+func randomTurn(
+	s *game.State,
+	rng *rand.Rand,
+) (*game.State, notation.Turn) {
+	switch {
+	case s.IsDeploying():
+		id := s.CurrentPlayer()
+		zone := s.Rules().HomeZones().Cells(id)
+		rng.Shuffle(len(zone), func(i, j int) {
+			zone[i], zone[j] = zone[j], zone[i]
+		})
+		cells := zone[:len(s.PlayerPieces(id))]
+		next, err := game.Deploy(s, cells)
+		if err != nil {
+			panic(err)
+		}
+		return next, notation.Turn{Cells: cells}
+	case s.Rules().PlayMode() == game.SimultaneousPlays:
+		var p game.Play
+		ps := make(map[game.PlayerID]game.Play)
+		for _, id := range s.Rules().PlayerIDs() {
+			legal := game.LegalPlays(s.AsPlayer(id))
+			ps[id] = legal[rng.Intn(len(legal))]
+			p = append(p, ps[id]...)
+		}
+		return game.NextStateWithPlays(s, ps), notation.Turn{Play: p}
+	default:
+		legal := game.LegalPlays(s)
+		p := legal[rng.Intn(len(legal))]
+		return game.NextStateWithPlay(s, p), notation.Turn{Play: p}
+	}
+}
+
+// TestReadRecordErrors tests that notation.ReadRecord rejects malformed
+// notation.Records and ones which don't replay.
+func TestReadRecordErrors(t *testing.T) {
+	t.Parallel()
+	for _, text := range []string{
+		"[Rules standard]\n",
+		"[Rules \"pieces=x\"]\n",
+		"[Rules \"pieces=0\"]\n",
+		"[Rules \"size=1x1\"]\n",
+		"[Rules \"standard\"]\n\n2. 3:S\n",
+		"[Rules \"standard\"]\n\n1. 3:S\n[Result \"*\"]\n",
+		"[Rules \"standard\"]\n\n1. 11:S\n",
+		"[Result \"1\"]\n\n1. 3:S\n",
+		"[Rules \"zones=1.../..../..../2...\"]\n\n1. 1:S\n",
+		"[Rules \"maxturns=1\"]\n\n1. -\n2. -\n",
+	} {
+		_, err := notation.ReadRecord(strings.NewReader(text))
+		if err == nil {
+			t.Errorf("notation.ReadRecord(%q) error = nil", text)
+		}
+	}
+}
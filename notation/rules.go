@@ -0,0 +1,410 @@
+package notation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+)
+
+// StandardRules is the reference to game.StandardRules.
+const StandardRules = "standard"
+
+// FormatRules returns the reference to the game.Rules.
+//
+// References are the options which differ from game.StandardRules separated by
+// semicolons in the order of the table below, or StandardRules if none do.
+// Each option has the form key=value:
+//
+//	timer       turn duration such as 30s
+//	pieces      pieces of each player
+//	life        life of each piece
+//	damage      damage of each piece
+//	lifeup      life gained by leveling up
+//	damageup    damage gained by leveling up
+//	players     number of players
+//	size        board width and height such as 11x7 if not 2n+1 by 2n+1
+//	maxturns    turns before a draw
+//	repetition  repetitions of a position before a draw
+//	timeout     "empty" or "forfeit"
+//	mode        "alternating" or "simultaneous"
+//	vision      fog of war vision
+//	territory   percent of open cells owned to win by territory control
+//	pattern     name of a common game.Pattern
+//	roster1-4   comma-separated names of common game.Classes
+//	terrain     map of blocked cells
+//	layout      map of start cells
+//	zones       map of home zones
+//
+// Maps have the form accepted by game.ParseTerrain with rows separated by
+// slashes. Cells are assigned in reading order. An error is returned if the
+// game.Rules have a game.Pattern or game.Class which isn't commonly used.
+func FormatRules(r game.Rules) (string, error) {
+	s := game.StandardRules
+	var opts []string
+	add := func(key string, v, standard any) {
+		if v != standard {
+			opts = append(opts, fmt.Sprintf("%s=%v", key, v))
+		}
+	}
+	add("timer", r.TimerDuration(), s.TimerDuration())
+	add("pieces", r.PieceCount(), s.PieceCount())
+	add("life", r.Life(), s.Life())
+	add("damage", r.Damage(), s.Damage())
+	add("lifeup", r.LifeIncrease(), s.LifeIncrease())
+	add("damageup", r.DamageIncrease(), s.DamageIncrease())
+	add("players", r.PlayerCount(), s.PlayerCount())
+	// The board size is left out when it's the size game.NewRules gives the
+	// number of pieces.
+	d := game.NewRules(
+		r.TimerDuration(),
+		r.PieceCount(), r.Life(), r.Damage(),
+		r.LifeIncrease(), r.DamageIncrease(),
+	)
+	add(
+		"size",
+		fmt.Sprintf("%dx%d", r.BoardWidth(), r.BoardHeight()),
+		fmt.Sprintf("%dx%d", d.BoardWidth(), d.BoardHeight()),
+	)
+	add("maxturns", r.MaxTurns(), s.MaxTurns())
+	add("repetition", r.RepetitionLimit(), s.RepetitionLimit())
+	add("timeout", timeoutName(r.TimeoutPolicy()), "empty")
+	add("mode", r.PlayMode(), game.AlternatingPlays)
+	add("vision", r.Vision(), s.Vision())
+	add("territory", r.TerritoryControl(), s.TerritoryControl())
+	pattern := r.Pattern()
+	if _, ok := commonPattern(pattern.Name()); !ok || !samePattern(
+		pattern, mustPattern(pattern.Name()),
+	) {
+		return "", fmt.Errorf("pattern %q isn't common", pattern.Name())
+	}
+	add("pattern", pattern.Name(), s.Pattern().Name())
+	for _, id := range r.PlayerIDs() {
+		var names []string
+		for _, c := range r.Roster(id) {
+			common, ok := commonClass(c.Name())
+			if !ok || common != c {
+				return "", fmt.Errorf(
+					"class %q isn't common",
+					c.Name(),
+				)
+			}
+			names = append(names, c.Name())
+		}
+		add(fmt.Sprintf("roster%d", id), strings.Join(names, ","), "")
+	}
+	w, h := r.BoardWidth(), r.BoardHeight()
+	if len(r.Terrain().Blocked()) != 0 {
+		add("terrain", formatMap(r.Terrain().Map(w, h)), "")
+	}
+	if r.Layout() != nil {
+		add("layout", formatMap(r.Layout().Map(w, h)), "")
+	}
+	if r.HomeZones() != nil {
+		add("zones", formatMap(r.HomeZones().Map(w, h)), "")
+	}
+	if len(opts) == 0 {
+		return StandardRules, nil
+	}
+	return strings.Join(opts, ";"), nil
+}
+
+// ParseRules from the reference FormatRules returns.
+//
+// Options can be given in any order and options which are left out have the
+// value they have in game.StandardRules. An error is returned if an option
+// isn't known, its value is invalid, or game.ValidateRules rejects the
+// game.Rules.
+func ParseRules(ref string) (game.Rules, error) {
+	s := game.StandardRules
+	if ref == StandardRules || ref == "" {
+		return s, nil
+	}
+	td := s.TimerDuration()
+	pc, l, d := s.PieceCount(), s.Life(), s.Damage()
+	li, di := s.LifeIncrease(), s.DamageIncrease()
+	var size string
+	var edits []func(game.Rules) (game.Rules, error)
+	edit := func(f func(game.Rules) game.Rules) {
+		edits = append(edits, func(r game.Rules) (game.Rules, error) {
+			return f(r), nil
+		})
+	}
+	for _, opt := range strings.Split(ref, ";") {
+		key, v, ok := strings.Cut(opt, "=")
+		if !ok {
+			return s, fmt.Errorf("option %q has no value", opt)
+		}
+		var err error
+		switch key {
+		case "timer":
+			td, err = time.ParseDuration(v)
+		case "pieces":
+			pc, err = strconv.Atoi(v)
+		case "life":
+			l, err = strconv.Atoi(v)
+		case "damage":
+			d, err = strconv.Atoi(v)
+		case "lifeup":
+			li, err = strconv.Atoi(v)
+		case "damageup":
+			di, err = strconv.Atoi(v)
+		case "players", "maxturns", "repetition", "vision",
+			"territory":
+			var n int
+			n, err = strconv.Atoi(v)
+			edit(intOptions[key](n))
+		case "size":
+			size = v
+		case "timeout":
+			tp, ok := timeoutPolicies[v]
+			if !ok {
+				err = fmt.Errorf("unknown timeout policy %q", v)
+			}
+			edit(func(r game.Rules) game.Rules {
+				return r.WithTimeoutPolicy(tp)
+			})
+		case "mode":
+			pm, ok := playModes[v]
+			if !ok {
+				err = fmt.Errorf("unknown play mode %q", v)
+			}
+			edit(func(r game.Rules) game.Rules {
+				return r.WithPlayMode(pm)
+			})
+		case "pattern":
+			p, ok := commonPattern(v)
+			if !ok {
+				err = fmt.Errorf("unknown pattern %q", v)
+			}
+			edit(func(r game.Rules) game.Rules {
+				return r.WithPattern(p)
+			})
+		case "roster1", "roster2", "roster3", "roster4":
+			id := game.PlayerID(key[len(key)-1] - '0')
+			var classes []game.Class
+			classes, err = ParseRoster(v)
+			edit(func(r game.Rules) game.Rules {
+				return r.WithRoster(id, classes...)
+			})
+		case "terrain", "layout", "zones":
+			rows, set := parseMap(v), mapOptions[key]
+			edits = append(
+				edits,
+				func(r game.Rules) (game.Rules, error) {
+					return set(r, rows)
+				},
+			)
+		default:
+			err = fmt.Errorf("unknown option %q", key)
+		}
+		if err != nil {
+			return s, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	r := game.NewRules(td, pc, l, d, li, di)
+	if size != "" {
+		var w, h int
+		_, err := fmt.Sscanf(size, "%dx%d", &w, &h)
+		if err != nil || w <= 0 || h <= 0 {
+			return s, fmt.Errorf("invalid size %q", size)
+		}
+		r = r.WithBoardSize(w, h)
+	}
+	for _, e := range edits {
+		var err error
+		if r, err = e(r); err != nil {
+			return s, err
+		}
+	}
+	if err := game.ValidateRules(r); err != nil {
+		return s, err
+	}
+	return r, nil
+}
+
+// intOptions which set a number in game.Rules by key.
+var intOptions = map[string]func(int) func(game.Rules) game.Rules{
+	"players": func(n int) func(game.Rules) game.Rules {
+		return func(r game.Rules) game.Rules {
+			return r.WithPlayerCount(n)
+		}
+	},
+	"maxturns": func(n int) func(game.Rules) game.Rules {
+		return func(r game.Rules) game.Rules {
+			return r.WithMaxTurns(n)
+		}
+	},
+	"repetition": func(n int) func(game.Rules) game.Rules {
+		return func(r game.Rules) game.Rules {
+			return r.WithRepetitionLimit(n)
+		}
+	},
+	"vision": func(n int) func(game.Rules) game.Rules {
+		return func(r game.Rules) game.Rules {
+			return r.WithVision(n)
+		}
+	},
+	"territory": func(n int) func(game.Rules) game.Rules {
+		return func(r game.Rules) game.Rules {
+			return r.WithTerritoryControl(n)
+		}
+	},
+}
+
+// mapOptions which set a map in game.Rules by key.
+var mapOptions = map[string]func(game.Rules, []string) (game.Rules, error){
+	"terrain": func(r game.Rules, rows []string) (game.Rules, error) {
+		t, err := game.ParseTerrain(rows)
+		if err != nil {
+			return r, err
+		}
+		return r.WithTerrain(t), nil
+	},
+	"layout": func(r game.Rules, rows []string) (game.Rules, error) {
+		l, err := game.ParseLayout(rows)
+		if err != nil {
+			return r, err
+		}
+		return r.WithLayout(l), nil
+	},
+	"zones": func(r game.Rules, rows []string) (game.Rules, error) {
+		l, err := game.ParseLayout(rows)
+		if err != nil {
+			return r, err
+		}
+		return r.WithHomeZones(l), nil
+	},
+}
+
+// timeoutPolicies by name.
+var timeoutPolicies = map[string]game.TimeoutPolicy{
+	"empty":   game.EmptyPlayOnTimeout,
+	"forfeit": game.ForfeitOnTimeout,
+}
+
+// timeoutName returns the name of the game.TimeoutPolicy in references.
+func timeoutName(tp game.TimeoutPolicy) string {
+	for name, x := range timeoutPolicies {
+		if x == tp {
+			return name
+		}
+	}
+	return ""
+}
+
+// playModes by name.
+var playModes = map[string]game.PlayMode{
+	game.AlternatingPlays.String():  game.AlternatingPlays,
+	game.SimultaneousPlays.String(): game.SimultaneousPlays,
+}
+
+// commonPattern returns the common game.Pattern with the name and true if
+// there is one.
+func commonPattern(name string) (*game.Pattern, bool) {
+	for _, p := range game.Patterns() {
+		if p.Name() == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// mustPattern returns the common game.Pattern with the name or nil if there
+// isn't one.
+func mustPattern(name string) *game.Pattern {
+	p, _ := commonPattern(name)
+	return p
+}
+
+// samePattern returns true iff the game.Patterns move the same way.
+func samePattern(a, b *game.Pattern) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	ao, bo := a.Offsets(), b.Offsets()
+	if a.Slides() != b.Slides() || len(ao) != len(bo) {
+		return false
+	}
+	for i := range ao {
+		if ao[i] != bo[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// commonClass returns the common game.Class with the name and true if there is
+// one.
+func commonClass(name string) (game.Class, bool) {
+	for _, c := range game.Classes() {
+		if c.Name() == name {
+			return c, true
+		}
+	}
+	return game.StandardClass, false
+}
+
+// ParseRoster returns the common game.Classes with the comma-separated names
+// in order.
+//
+// Surrounding whitespace is ignored. An error is returned if a name isn't the
+// name of a common game.Class.
+func ParseRoster(v string) ([]game.Class, error) {
+	var classes []game.Class
+	for _, name := range strings.Split(v, ",") {
+		c, ok := commonClass(strings.TrimSpace(name))
+		if !ok {
+			return nil, fmt.Errorf("unknown class %q", name)
+		}
+		classes = append(classes, c)
+	}
+	return classes, nil
+}
+
+// WithRoster returns the game.Rules where every game.Player's game.Pieces have
+// the game.Classes ParseRoster returns for the roster in order, or the
+// game.Rules if the roster is empty.
+//
+// An error is returned along with the game.Rules if the roster is invalid.
+func WithRoster(r game.Rules, roster string) (game.Rules, error) {
+	if roster == "" {
+		return r, nil
+	}
+	classes, err := ParseRoster(roster)
+	if err != nil {
+		return r, err
+	}
+	for _, id := range r.PlayerIDs() {
+		r = r.WithRoster(id, classes...)
+	}
+	return r, nil
+}
+
+// WithPattern returns the game.Rules where game.Pieces move with the common
+// game.Pattern with the name, or the game.Rules if the name is empty.
+//
+// An error is returned along with the game.Rules if no common game.Pattern has
+// the name.
+func WithPattern(r game.Rules, name string) (game.Rules, error) {
+	if name == "" {
+		return r, nil
+	}
+	p, ok := commonPattern(name)
+	if !ok {
+		return r, fmt.Errorf("unknown pattern %q", name)
+	}
+	return r.WithPattern(p), nil
+}
+
+// formatMap returns the rows of the map separated by slashes.
+func formatMap(rows []string) string {
+	return strings.Join(rows, "/")
+}
+
+// parseMap returns the rows of a map separated by slashes.
+func parseMap(v string) []string {
+	return strings.Split(v, "/")
+}
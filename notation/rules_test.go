@@ -0,0 +1,174 @@
+package notation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jwowillo/landgrab/game"
+	"github.com/jwowillo/landgrab/notation"
+)
+
+// TestRules tests that game.Rules are formatted with notation.FormatRules and
+// parsed back to the same game.Rules with notation.ParseRules.
+func TestRules(t *testing.T) {
+	t.Parallel()
+	small := game.StandardRules.WithBoardSize(5, 4)
+	terrain, err := game.ParseTerrain([]string{
+		".....",
+		".#...",
+		"...#.",
+		".....",
+	})
+	if err != nil {
+		t.Fatalf("game.ParseTerrain(rows) error = %v", err)
+	}
+	layout, err := game.ParseLayout([]string{
+		"11111",
+		".....",
+		".....",
+		"22222",
+	})
+	if err != nil {
+		t.Fatalf("game.ParseLayout(rows) error = %v", err)
+	}
+	cases := []struct {
+		rules game.Rules
+		ref   string
+	}{
+		{rules: game.StandardRules, ref: "standard"},
+		{
+			rules: game.NewRules(time.Second, 3, 2, 1, 1, 0),
+			ref:   "timer=1s;pieces=3;life=2;damageup=0",
+		},
+		{
+			rules: game.StandardRules.WithPlayerCount(4).
+				WithMaxTurns(100).
+				WithRepetitionLimit(0).
+				WithTimeoutPolicy(game.ForfeitOnTimeout).
+				WithPlayMode(game.SimultaneousPlays).
+				WithVision(2).
+				WithTerritoryControl(60),
+			ref: "players=4;maxturns=100;repetition=0;" +
+				"timeout=forfeit;mode=simultaneous;vision=2;" +
+				"territory=60",
+		},
+		{
+			rules: game.StandardRules.
+				WithPattern(game.KnightPattern).
+				WithRoster(game.Player1, game.Scout, game.Tank).
+				WithRoster(game.Player2, game.Striker),
+			ref: "pattern=knight;" +
+				"roster1=scout,tank;roster2=striker",
+		},
+		{
+			rules: small.WithTerrain(terrain).
+				WithLayout(layout).
+				WithHomeZones(game.SideZones(small, 1)),
+			ref: "size=5x4;" +
+				"terrain=...../.#.../...#./.....;" +
+				"layout=11111/...../...../22222;" +
+				"zones=11111/3...4/3...4/22222",
+		},
+	}
+	for _, c := range cases {
+		ref, err := notation.FormatRules(c.rules)
+		if err != nil || ref != c.ref {
+			t.Errorf(
+				"notation.FormatRules(r) = %q, %v, want %q, %v",
+				ref, err, c.ref, nil,
+			)
+		}
+		r, err := notation.ParseRules(c.ref)
+		if err != nil {
+			t.Errorf(
+				"notation.ParseRules(%q) error = %v, want %v",
+				c.ref, err, nil,
+			)
+			continue
+		}
+		if got, _ := notation.FormatRules(r); got != c.ref {
+			t.Errorf(
+				"notation.ParseRules(%q) formats as %q",
+				c.ref, got,
+			)
+		}
+	}
+}
+
+// TestRulesErrors tests that notation.FormatRules rejects game.Rules which
+// aren't commonly used and notation.ParseRules rejects invalid references and
+// ones game.ValidateRules rejects.
+func TestRulesErrors(t *testing.T) {
+	t.Parallel()
+	custom := game.NewPattern("king", false, game.NewOffset(1, 0))
+	for _, r := range []game.Rules{
+		game.StandardRules.WithPattern(custom),
+		game.StandardRules.WithRoster(
+			game.Player1,
+			game.NewClass("scout", 1, 1, 1, 1, 1),
+		),
+	} {
+		if ref, err := notation.FormatRules(r); err == nil {
+			t.Errorf(
+				"notation.FormatRules(r) = %q, want error",
+				ref,
+			)
+		}
+	}
+	for _, ref := range []string{
+		"pieces", "pieces=x", "size=0x4", "unknown=1", "timeout=never",
+		"mode=turns", "pattern=queen", "roster1=king", "terrain=.x.",
+		"pieces=0", "pieces=1000", "size=1x1", "size=1000x1000",
+	} {
+		if _, err := notation.ParseRules(ref); err == nil {
+			t.Errorf("notation.ParseRules(%q) error = nil", ref)
+		}
+	}
+}
+
+// TestWithRoster tests that notation.WithRoster gives every game.Player's
+// game.Pieces the named game.Classes and rejects unknown names.
+func TestWithRoster(t *testing.T) {
+	t.Parallel()
+	r := game.StandardRules.WithPlayerCount(3)
+	got, err := notation.WithRoster(r, "scout, tank")
+	if err != nil {
+		t.Fatalf("notation.WithRoster(r, roster) error = %v", err)
+	}
+	for _, id := range r.PlayerIDs() {
+		roster := got.Roster(id)
+		if len(roster) != 2 || roster[0] != game.Scout ||
+			roster[1] != game.Tank {
+			t.Errorf(
+				"got.Roster(%v) = %v, want scout, tank",
+				id, roster,
+			)
+		}
+	}
+	if same, err := notation.WithRoster(r, ""); err != nil || same != r {
+		t.Errorf("notation.WithRoster(r, %q) changed r", "")
+	}
+	if _, err := notation.WithRoster(r, "scout,queen"); err == nil {
+		t.Errorf("notation.WithRoster(r, roster) error = nil")
+	}
+}
+
+// TestWithPattern tests that notation.WithPattern gives game.Pieces the named
+// common game.Pattern and rejects unknown names.
+func TestWithPattern(t *testing.T) {
+	t.Parallel()
+	r := game.StandardRules
+	got, err := notation.WithPattern(r, "knight")
+	if err != nil || got.Pattern() != game.KnightPattern {
+		t.Errorf(
+			"notation.WithPattern(r, %q) = %v, %v, want %v, %v",
+			"knight", got.Pattern().Name(), err, "knight", nil,
+		)
+	}
+	if same, err := notation.WithPattern(r, ""); err != nil || same != r {
+		t.Errorf("notation.WithPattern(r, %q) changed r", "")
+	}
+	if _, err := notation.WithPattern(r, "queen"); err == nil {
+		t.Errorf("notation.WithPattern(r, %q) error = nil", "queen")
+	}
+}
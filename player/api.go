@@ -1,6 +1,7 @@
 package player
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"log"
@@ -20,6 +21,9 @@ import (
 // JSON form and return a convert.JSONPlay in JSON form wrapped in a data
 // payload.
 //
+// In games with fog of war, the game.State is the game.Player's
+// game.PlayerView, so only what they can see is sent.
+//
 // API is a special game.DescribedPlayer in that it needs its URL initialized.
 type API struct {
 	url    string
@@ -53,6 +57,20 @@ func (p *API) Description() string {
 // An empty game.Play is returned as nil if the external API doesnt return a
 // game.Play correctly.
 func (p *API) Play(s *game.State) game.Play {
+	play, err := p.PlayContext(context.Background(), s)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	return play
+}
+
+// PlayContext is Play which cancels the request to the external API once the
+// context.Context is done and returns any error instead of logging it.
+func (p *API) PlayContext(
+	ctx context.Context,
+	s *game.State,
+) (game.Play, error) {
 	if p.client == nil {
 		p.client = &http.Client{Transport: &http.Transport{
 			Dial: makeTimeout(s.Rules().TimerDuration()),
@@ -61,41 +79,34 @@ func (p *API) Play(s *game.State) game.Play {
 	js := convert.StateToJSONState(s)
 	bs, err := json.Marshal(js)
 	if err != nil {
-		log.Println(err)
-		return nil
+		return nil, err
 	}
 	query := "?state=" + url.QueryEscape(string(bs))
-	resp, err := p.client.Get(p.url + query)
+	req, err := http.NewRequest(http.MethodGet, p.url+query, nil)
 	if err != nil {
-		log.Println(err)
-		return nil
+		return nil, err
 	}
-	body, err := ioutil.ReadAll(resp.Body)
+	resp, err := p.client.Do(req.WithContext(ctx))
 	if err != nil {
-		log.Println(err)
-		return nil
+		return nil, err
 	}
-	err = resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		log.Println(err)
-		return nil
+		resp.Body.Close()
+		return nil, err
+	}
+	if err = resp.Body.Close(); err != nil {
+		return nil, err
 	}
 	raw := make(map[string]interface{})
 	if err = json.Unmarshal(body, &raw); err != nil {
-		log.Println(err)
-		return nil
+		return nil, err
 	}
 	bs, err = json.Marshal(raw["data"])
 	if err != nil {
-		log.Println(err)
-		return nil
+		return nil, err
 	}
-	play, err := convert.JSONToPlay(bs)
-	if err != nil {
-		log.Println(err)
-		return nil
-	}
-	return play
+	return convert.JSONToPlay(bs)
 }
 
 // makeTimeout makes a net.Con which waits the given time.Duration before timing
@@ -1,6 +1,10 @@
 package player
 
-import "github.com/jwowillo/landgrab/game"
+import (
+	"context"
+
+	"github.com/jwowillo/landgrab/game"
+)
 
 // Greedy game.Player chooses the game.Play with the greatest value from all
 // legal game.Plays.
@@ -24,5 +28,19 @@ func (p Greedy) Description() string {
 // Play the turn by returning a random game.Play in the set of the highest-value
 // legal game.Plays from the game.State.
 func (p Greedy) Play(s *game.State) game.Play {
-	return random(best(s))
+	play, _ := p.PlayContext(context.Background(), s)
+	return play
+}
+
+// PlayContext is Play which stops considering game.Plays once the
+// context.Context is done.
+func (p Greedy) PlayContext(
+	ctx context.Context,
+	s *game.State,
+) (game.Play, error) {
+	ps, err := best(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	return random(ps), nil
 }
@@ -1,6 +1,7 @@
 package player_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/jwowillo/landgrab/game"
@@ -12,8 +13,27 @@ func BenchmarkGreedy(b *testing.B) {
 	p2 := player.Factory.Player("greedy")
 	for i := 0; i < b.N; i++ {
 		s := game.NewState(game.StandardRules, p1, p2)
-		for s.Winner() == game.NoPlayer {
+		for !s.IsOver() {
 			s = game.NextState(s)
 		}
 	}
 }
+
+// TestGreedyPlayContext tests that player.Greedy stops considering game.Plays
+// once its context.Context is done.
+func TestGreedyPlayContext(t *testing.T) {
+	t.Parallel()
+	p := player.Factory.Player("greedy").(game.ContextPlayer)
+	s := game.NewState(game.StandardRules, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.PlayContext(ctx, s); err != context.Canceled {
+		t.Errorf(
+			"p.PlayContext(ctx, s) error = %v, want %v",
+			err, context.Canceled,
+		)
+	}
+	if _, err := p.PlayContext(context.Background(), s); err != nil {
+		t.Errorf("p.PlayContext(ctx, s) error = %v, want %v", err, nil)
+	}
+}
@@ -1,6 +1,10 @@
 package player
 
-import "github.com/jwowillo/landgrab/game"
+import (
+	"context"
+
+	"github.com/jwowillo/landgrab/game"
+)
 
 // Human makes a set Play provided to it assuming the entity setting the
 // game.Play can see the current game.State.
@@ -35,3 +39,12 @@ func (p *Human) Description() string {
 func (p *Human) Play(s *game.State) game.Play {
 	return p.play
 }
+
+// PlayContext returns the preset game.Play since it is always immediately
+// available.
+func (p *Human) PlayContext(
+	_ context.Context,
+	s *game.State,
+) (game.Play, error) {
+	return p.Play(s), nil
+}
@@ -13,8 +13,10 @@
 package player
 
 import (
+	"context"
 	"encoding/json"
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/jwowillo/landgrab/convert"
@@ -76,7 +78,30 @@ func init() {
 }
 
 // gen random values.
-var gen = rand.New(rand.NewSource(time.Now().Unix()))
+//
+// gen is shared by every game.DescribedPlayer, which can play at the same time
+// in games with game.SimultaneousPlays, so its source is locked.
+var gen = rand.New(&lockedSource{src: rand.NewSource(time.Now().Unix())})
+
+// lockedSource is a rand.Source which is safe to use from multiple goroutines.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+// Int63 returns the next value from the wrapped rand.Source.
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+// Seed the wrapped rand.Source.
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
 
 // Commonly used numeric constants.
 const (
@@ -94,16 +119,23 @@ func random(ps []game.Play) game.Play {
 // best game.Plays from the given game.State.
 //
 // Returns a list of game.Plays that all had the highest found value from the
-// given game.State. To find this, the value of the next game.State from the
-// current one is minimized, since the next game.State is for the enemy.
-func best(s *game.State) []game.Play {
-	best := max
+// given game.State. To find this, the value of the next game.State for the
+// current game.Player is maximized. Only one game.Play is considered for each
+// distinct next game.State.
+//
+// The context.Context's error is returned if it is done before all the legal
+// game.Plays are considered.
+func best(ctx context.Context, s *game.State) ([]game.Play, error) {
+	id := s.CurrentPlayer()
+	best := min
 	bestDistance := max
 	var bestPlays []game.Play
-	for p := range game.LegalPlaysPipe(s) {
-		n := game.NextStateWithPlay(s, p)
-		v := value(n)
-		d := totalDistance(n)
+	for p, n := range game.DistinctOutcomes(s) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		v := value(n, id)
+		d := totalDistance(n, id)
 		if v == best {
 			if d < bestDistance {
 				bestDistance = d
@@ -112,42 +144,57 @@ func best(s *game.State) []game.Play {
 				bestPlays = append(bestPlays, p)
 			}
 		}
-		if v < best {
+		if v > best {
 			best = v
 			bestDistance = d
 			bestPlays = []game.Play{p}
 		}
 	}
-	return bestPlays
+	return bestPlays, nil
 }
 
-// value of the game.States is the sum of the current game.Player's lifes and
-// damages minus the sum o fthe next game.Player's lifes and damages.
+// value of the game.States for the game.Player with the game.PlayerID is the
+// sum of the game.Player's lifes and damages minus the sum of every other
+// game.Player's lifes and damages.
 //
-// Two special cases are max is returned if a winning game.State is passed and
-// min is returned otherwise.
-func value(s *game.State) int {
+// Two special cases are max is returned if the game.Player has won and min is
+// returned if another game.Player has won.
+func value(s *game.State, id game.PlayerID) int {
 	x := 0
-	if s.Winner() == s.NextPlayer() {
+	switch s.Winner() {
+	case id:
+		return max
+	case game.NoPlayer:
+	default:
 		return min
 	}
-	if s.Winner() == s.CurrentPlayer() {
-		return max
+	for _, p := range s.PlayerPieces(id) {
+		x += p.Life() + p.Damage()
 	}
-	for _, p := range s.NextPlayerPieces() {
+	for _, p := range enemyPieces(s, id) {
 		x -= p.Life() + p.Damage()
 	}
-	for _, p := range s.CurrentPlayerPieces() {
-		x += p.Life() + p.Damage()
-	}
 	return x
 }
 
-// totalDistance using the manhattan metric between all game.Pieces.
-func totalDistance(s *game.State) int {
+// enemyPieces returns the game.Pieces which don't belong to the game.Player
+// with the game.PlayerID.
+func enemyPieces(s *game.State, id game.PlayerID) []game.Piece {
+	var ps []game.Piece
+	for _, p := range s.Pieces() {
+		if s.PlayerForPiece(p) != id {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// totalDistance using the manhattan metric between all game.Pieces of the
+// game.Player with the game.PlayerID and their enemies.
+func totalDistance(s *game.State, id game.PlayerID) int {
 	total := 0
-	for _, pa := range s.CurrentPlayerPieces() {
-		for _, pb := range s.NextPlayerPieces() {
+	for _, pa := range s.PlayerPieces(id) {
+		for _, pb := range enemyPieces(s, id) {
 			a := s.CellForPiece(pa)
 			b := s.CellForPiece(pb)
 			total += manhattanDistance(a, b)
@@ -1,6 +1,10 @@
 package player
 
-import "github.com/jwowillo/landgrab/game"
+import (
+	"context"
+
+	"github.com/jwowillo/landgrab/game"
+)
 
 // Random game.Player chooses a random game.Play from all legal game.Plays.
 type Random struct{}
@@ -20,7 +24,40 @@ func (p Random) Description() string {
 	return "chooses a random play"
 }
 
+// Deploy the game.Pieces to random open game.Cells in the home zone.
+func (p Random) Deploy(s *game.State) []game.Cell {
+	id := s.CurrentPlayer()
+	var open []game.Cell
+	for _, c := range s.Rules().HomeZones().Cells(id) {
+		x := s.PieceForCell(c)
+		held := x != game.NoPiece && s.PlayerForPiece(x) != id
+		if !s.Rules().IsBlocked(c) && !held {
+			open = append(open, c)
+		}
+	}
+	n := len(s.CurrentPlayerPieces())
+	if len(open) < n {
+		return nil
+	}
+	gen.Shuffle(len(open), func(i, j int) {
+		open[i], open[j] = open[j], open[i]
+	})
+	return open[:n]
+}
+
 // Play a random game.Play in the set of legal game.Plays from the game.State.
 func (p Random) Play(s *game.State) game.Play {
 	return random(game.LegalPlays(s))
 }
+
+// PlayContext is Play which returns the context.Context's error instead if it
+// is done.
+func (p Random) PlayContext(
+	ctx context.Context,
+	s *game.State,
+) (game.Play, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return p.Play(s), nil
+}
@@ -0,0 +1,28 @@
+package player_test
+
+import (
+	"testing"
+
+	"github.com/jwowillo/landgrab/game"
+	"github.com/jwowillo/landgrab/player"
+)
+
+// TestRandomSimultaneousPlays tests that player.Randoms can choose their
+// game.Plays at the same time in games with game.SimultaneousPlays.
+//
+// Run with -race to catch players sharing random state unsafely.
+func TestRandomSimultaneousPlays(t *testing.T) {
+	t.Parallel()
+	r := game.StandardRules.WithPlayMode(game.SimultaneousPlays)
+	s := game.NewState(
+		r,
+		player.Factory.Player("random"),
+		player.Factory.Player("random"),
+	)
+	for i := 0; i < 50 && !s.IsOver(); i++ {
+		s = game.NextState(s)
+	}
+	if s.Turn() == 0 {
+		t.Errorf("s.Turn() = %d, want > 0", s.Turn())
+	}
+}
@@ -1,6 +1,10 @@
 package player
 
-import "github.com/jwowillo/landgrab/game"
+import (
+	"context"
+
+	"github.com/jwowillo/landgrab/game"
+)
 
 // Search game.Player chooses the game.Play which leads to the greatest value
 // game.State within a search radius.
@@ -24,5 +28,18 @@ func (p Search) Description() string {
 // Play by searching for the highest value game.State within a set search radius
 // and returning the game.Play that leads to it.
 func (p Search) Play(s *game.State) game.Play {
-	return random(best(s))
+	play, _ := p.PlayContext(context.Background(), s)
+	return play
+}
+
+// PlayContext is Play which stops searching once the context.Context is done.
+func (p Search) PlayContext(
+	ctx context.Context,
+	s *game.State,
+) (game.Play, error) {
+	ps, err := best(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	return random(ps), nil
 }